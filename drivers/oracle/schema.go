@@ -91,6 +91,12 @@ func getTables(db *sql.DB, owner string, verifyData, verifyRowCounts bool) ([]ma
 		}
 		table["foreign_keys"] = foreignKeys
 
+		primaryKey, err := getPrimaryKey(db, owner, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get primary key for table %s: %w", tableName, err)
+		}
+		table["primary_key"] = primaryKey
+
 		if verifyRowCounts {
 			var rowCount int64
 			err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", owner, tableName)).Scan(&rowCount)
@@ -239,6 +245,37 @@ func getIndexes(db *sql.DB, owner, tableName string) ([]map[string]interface{},
 	return indexes, nil
 }
 
+// getPrimaryKey returns the primary key columns in key order. Primary key
+// indexes are excluded from getIndexes, so the key is recorded separately.
+func getPrimaryKey(db *sql.DB, owner, tableName string) ([]string, error) {
+	query := `
+		SELECT cc.column_name
+		FROM all_constraints c
+		JOIN all_cons_columns cc ON c.owner = cc.owner AND c.constraint_name = cc.constraint_name
+		WHERE c.owner = :1
+			AND c.table_name = :2
+			AND c.constraint_type = 'P'
+		ORDER BY cc.position
+	`
+
+	rows, err := db.Query(query, strings.ToUpper(owner), strings.ToUpper(tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	primaryKey := []string{}
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, err
+		}
+		primaryKey = append(primaryKey, columnName)
+	}
+
+	return primaryKey, nil
+}
+
 func getForeignKeys(db *sql.DB, owner, tableName string) ([]map[string]interface{}, error) {
 	query := `
 		SELECT
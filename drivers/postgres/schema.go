@@ -83,6 +83,12 @@ func getTables(db *sql.DB, verifyData, verifyRowCounts bool) ([]map[string]inter
 		}
 		table["foreign_keys"] = foreignKeys
 
+		primaryKey, err := getPrimaryKey(db, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get primary key for table %s: %w", tableName, err)
+		}
+		table["primary_key"] = primaryKey
+
 		if verifyRowCounts {
 			var rowCount int64
 			err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)).Scan(&rowCount)
@@ -205,6 +211,38 @@ func getIndexes(db *sql.DB, tableName string) ([]map[string]interface{}, error)
 	return indexes, nil
 }
 
+// getPrimaryKey returns the primary key columns in key order. Primary indexes
+// are excluded from getIndexes, so the key is recorded separately.
+func getPrimaryKey(db *sql.DB, tableName string) ([]string, error) {
+	query := `
+		SELECT a.attname
+		FROM pg_index ix
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey)
+		WHERE t.relname = $1
+			AND t.relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = 'public')
+			AND ix.indisprimary
+		ORDER BY array_position(ix.indkey, a.attnum)
+	`
+
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	primaryKey := []string{}
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, err
+		}
+		primaryKey = append(primaryKey, columnName)
+	}
+
+	return primaryKey, nil
+}
+
 func getForeignKeys(db *sql.DB, tableName string) ([]map[string]interface{}, error) {
 	query := `
 		SELECT
@@ -83,6 +83,12 @@ func getTables(db *sql.DB, database string, verifyData, verifyRowCounts bool) ([
 		}
 		table["foreign_keys"] = foreignKeys
 
+		primaryKey, err := getPrimaryKey(db, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get primary key for table %s: %w", tableName, err)
+		}
+		table["primary_key"] = primaryKey
+
 		if verifyRowCounts {
 			var rowCount int64
 			err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)).Scan(&rowCount)
@@ -243,6 +249,29 @@ func getTableChecksum(db *sql.DB, tableName string) (string, error) {
 	return fmt.Sprintf("%d", count.Int64), nil
 }
 
+// getPrimaryKey returns the primary key columns ordered by their key ordinal
+// (the pk field of PRAGMA table_info), which can differ from column order.
+func getPrimaryKey(db *sql.DB, tableName string) ([]string, error) {
+	query := fmt.Sprintf("SELECT name FROM pragma_table_info('%s') WHERE pk > 0 ORDER BY pk", tableName)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	primaryKey := []string{}
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, err
+		}
+		primaryKey = append(primaryKey, columnName)
+	}
+
+	return primaryKey, nil
+}
+
 func getForeignKeys(db *sql.DB, tableName string) ([]map[string]interface{}, error) {
 	rows, err := db.Query(fmt.Sprintf("PRAGMA foreign_key_list(%s)", tableName))
 	if err != nil {
@@ -81,6 +81,12 @@ func getTables(db *sql.DB, verifyData, verifyRowCounts bool) ([]map[string]inter
 		}
 		table["foreign_keys"] = foreignKeys
 
+		primaryKey, err := getPrimaryKey(db, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get primary key for table %s: %w", tableName, err)
+		}
+		table["primary_key"] = primaryKey
+
 		if verifyRowCounts {
 			var rowCount int64
 			err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM [%s]", tableName)).Scan(&rowCount)
@@ -224,6 +230,36 @@ func getIndexes(db *sql.DB, tableName string) ([]map[string]interface{}, error)
 	return indexes, nil
 }
 
+// getPrimaryKey returns the primary key columns in key order. Primary indexes
+// are excluded from getIndexes, so the key is recorded separately.
+func getPrimaryKey(db *sql.DB, tableName string) ([]string, error) {
+	query := `
+		SELECT COL_NAME(ic.object_id, ic.column_id) as column_name
+		FROM sys.indexes i
+		INNER JOIN sys.index_columns ic ON i.object_id = ic.object_id AND i.index_id = ic.index_id
+		WHERE i.object_id = OBJECT_ID(@p1)
+			AND i.is_primary_key = 1
+		ORDER BY ic.key_ordinal
+	`
+
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	primaryKey := []string{}
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, err
+		}
+		primaryKey = append(primaryKey, columnName)
+	}
+
+	return primaryKey, nil
+}
+
 func getForeignKeys(db *sql.DB, tableName string) ([]map[string]interface{}, error) {
 	query := `
 		SELECT
@@ -2,4 +2,7 @@ module github.com/ntancardoso/dbc
 
 go 1.25
 
-require github.com/joho/godotenv v1.5.1
+require (
+	github.com/cespare/xxhash/v2 v2.1.2
+	github.com/joho/godotenv v1.5.1
+)
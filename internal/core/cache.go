@@ -0,0 +1,101 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+// compareCacheVersion is part of every cache key; bump it whenever the
+// comparison logic or ChangeSet layout changes so old entries are ignored.
+const compareCacheVersion = 1
+
+var ErrCacheMiss = errors.New("comparison not cached")
+
+// CompareCache stores ChangeSets keyed by the file digests of the compared
+// snapshots, so re-rendering the same pair skips the diff.
+type CompareCache struct {
+	dir string
+}
+
+func NewCompareCache(dir string) *CompareCache {
+	return &CompareCache{
+		dir: dir,
+	}
+}
+
+// CompareCacheDir returns the cache location inside a snapshot directory.
+func CompareCacheDir(snapshotDir string) string {
+	return filepath.Join(snapshotDir, ".cache")
+}
+
+// CompareCacheKey derives the cache key from both snapshot file digests (see
+// SnapshotStorage.LoadWithDigest) and the options that affect the ChangeSet.
+func CompareCacheKey(baselineDigest, targetDigest string, opts CompareOptions) string {
+	sections := strings.Join(opts.Sections.List(), ",")
+	raw := fmt.Sprintf("v%d\n%s\n%s\n%s\n%d", compareCacheVersion, baselineDigest, targetDigest, sections, opts.MaxColumns)
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *CompareCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *CompareCache) Load(key string) (*models.ChangeSet, error) {
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	var changeSet models.ChangeSet
+	if err := json.Unmarshal(data, &changeSet); err != nil {
+		// A corrupt entry is treated as a miss and overwritten on the next save
+		return nil, ErrCacheMiss
+	}
+
+	return &changeSet, nil
+}
+
+func (c *CompareCache) Save(key string, changeSet *models.ChangeSet) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(changeSet)
+	if err != nil {
+		return fmt.Errorf("failed to marshal change set: %w", err)
+	}
+
+	if err := os.WriteFile(c.path(key), data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes all cached comparisons and returns how many were deleted.
+func (c *CompareCache) Clear() (int, error) {
+	matches, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+
+	for _, match := range matches {
+		if err := os.Remove(match); err != nil {
+			return 0, fmt.Errorf("failed to delete cache entry: %w", err)
+		}
+	}
+
+	return len(matches), nil
+}
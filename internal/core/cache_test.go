@@ -0,0 +1,139 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+func TestCompareCacheKey(t *testing.T) {
+	opts := DefaultCompareOptions()
+	key := CompareCacheKey("xxhash64:a", "xxhash64:b", opts)
+
+	if key != CompareCacheKey("xxhash64:a", "xxhash64:b", opts) {
+		t.Error("Expected cache key to be deterministic")
+	}
+	if key == CompareCacheKey("xxhash64:b", "xxhash64:a", opts) {
+		t.Error("Expected cache key to depend on comparison direction")
+	}
+
+	opts.Sections, _ = ParseSections("indexes")
+	if key == CompareCacheKey("xxhash64:a", "xxhash64:b", opts) {
+		t.Error("Expected cache key to depend on selected sections")
+	}
+}
+
+func TestCompareCache(t *testing.T) {
+	cache := NewCompareCache(CompareCacheDir(t.TempDir()))
+	key := CompareCacheKey("a", "b", DefaultCompareOptions())
+
+	if _, err := cache.Load(key); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Expected cache miss, got %v", err)
+	}
+
+	changeSet := &models.ChangeSet{
+		TablesAdded: []models.Table{{Name: "users"}},
+		Summary:     models.ChangeSummary{TablesAdded: 1},
+	}
+	if err := cache.Save(key, changeSet); err != nil {
+		t.Fatalf("Unexpected save error: %v", err)
+	}
+
+	cached, err := cache.Load(key)
+	if err != nil {
+		t.Fatalf("Unexpected load error: %v", err)
+	}
+	if cached.Summary.TablesAdded != 1 || cached.TablesAdded[0].Name != "users" {
+		t.Errorf("Unexpected cached change set: %+v", cached)
+	}
+
+	removed, err := cache.Clear()
+	if err != nil || removed != 1 {
+		t.Errorf("Expected 1 entry removed, got %d (err: %v)", removed, err)
+	}
+	if _, err := cache.Load(key); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Expected cache miss after clear, got %v", err)
+	}
+}
+
+func TestLoadWithDigest(t *testing.T) {
+	storage := NewSnapshotStorage(t.TempDir())
+	snapshot := &models.SchemaSnapshot{Key: "a", Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := storage.Save(snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	_, digest1, err := storage.LoadWithDigest("a")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, digest2, _ := storage.LoadWithDigest("a")
+	if digest1 != digest2 {
+		t.Error("Expected digest to be stable for the same file")
+	}
+
+	snapshot.Timestamp = snapshot.Timestamp.Add(time.Hour)
+	snapshot.Tables = []models.Table{{Name: "users"}}
+	if err := storage.Save(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	_, digest3, _ := storage.LoadWithDigest("a")
+	if digest1 == digest3 {
+		t.Error("Expected digest to change for a recaptured snapshot")
+	}
+}
+
+// benchmarkSnapshots builds a 2000-table × 30-column pair with a tenth of the
+// tables changed, saved to disk like real captures.
+func benchmarkSnapshots(b *testing.B) (storage *SnapshotStorage, cache *CompareCache) {
+	dir := b.TempDir()
+	storage = NewSnapshotStorage(dir)
+	cache = NewCompareCache(CompareCacheDir(dir))
+
+	for _, key := range []string{"baseline", "target"} {
+		snapshot := &models.SchemaSnapshot{Key: key, Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+		for i := 0; i < 2000; i++ {
+			table := wideTable(fmt.Sprintf("table_%d", i), 30)
+			if key == "target" && i%10 == 0 {
+				table.Columns[0].ColumnType = "bigint"
+				table.RowCount = 1
+			}
+			snapshot.Tables = append(snapshot.Tables, table)
+		}
+		if err := storage.Save(snapshot); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return storage, cache
+}
+
+func BenchmarkCompareRecompute(b *testing.B) {
+	storage, _ := benchmarkSnapshots(b)
+	baseline, _ := storage.Load("baseline")
+	target, _ := storage.Load("target")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CompareSnapshots(baseline, target)
+	}
+}
+
+func BenchmarkCompareCacheHit(b *testing.B) {
+	storage, cache := benchmarkSnapshots(b)
+	baseline, baselineDigest, _ := storage.LoadWithDigest("baseline")
+	target, targetDigest, _ := storage.LoadWithDigest("target")
+	opts := DefaultCompareOptions()
+	if err := cache.Save(CompareCacheKey(baselineDigest, targetDigest, opts), CompareSnapshots(baseline, target)); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cache.Load(CompareCacheKey(baselineDigest, targetDigest, opts)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
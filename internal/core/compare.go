@@ -4,31 +4,154 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ntancardoso/dbc/internal/models"
 )
 
+// DefaultMaxColumns is the per-table column count above which column-level
+// diffing is skipped so pathological tables cannot stall a comparison.
+const DefaultMaxColumns = 10000
+
+const (
+	SectionPrimaryKeys = "pks"
+	SectionColumns     = "columns"
+	SectionIndexes     = "indexes"
+	SectionForeignKeys = "fks"
+	SectionRowCounts   = "rowcounts"
+	SectionChecksums   = "checksums"
+)
+
+var AllSections = []string{SectionPrimaryKeys, SectionColumns, SectionIndexes, SectionForeignKeys, SectionRowCounts, SectionChecksums}
+
+// Sections is the set of change categories to compute. A nil set means all.
+type Sections map[string]bool
+
+// ParseSections parses a comma-separated list such as "indexes,fks".
+// An empty value selects every section.
+func ParseSections(value string) (Sections, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	sections := make(Sections)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(AllSections, name) {
+			return nil, fmt.Errorf("unknown section: %s (use %s)", name, strings.Join(AllSections, ", "))
+		}
+		sections[name] = true
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no sections given (use %s)", strings.Join(AllSections, ", "))
+	}
+	return sections, nil
+}
+
+// Without returns a copy of the set with one section removed.
+func (s Sections) Without(section string) Sections {
+	result := make(Sections)
+	for _, name := range AllSections {
+		if name != section && s.Has(name) {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+func (s Sections) Has(section string) bool {
+	return s == nil || s[section]
+}
+
+// List returns the selected sections in canonical order, or nil for all.
+func (s Sections) List() []string {
+	if s == nil {
+		return nil
+	}
+	var list []string
+	for _, section := range AllSections {
+		if s[section] {
+			list = append(list, section)
+		}
+	}
+	return list
+}
+
+type CompareOptions struct {
+	MaxColumns int      // Column-level diff limit per table (0 = unlimited)
+	Sections   Sections // Change categories to compute (nil = all)
+	Timings    *Timings // Optional phase timings, filled in when non-nil
+}
+
+func DefaultCompareOptions() CompareOptions {
+	return CompareOptions{
+		MaxColumns: DefaultMaxColumns,
+	}
+}
+
 func CompareSnapshots(baseline, target *models.SchemaSnapshot) *models.ChangeSet {
+	return CompareSnapshotsWithOptions(baseline, target, DefaultCompareOptions())
+}
+
+func CompareSnapshotsWithOptions(baseline, target *models.SchemaSnapshot, opts CompareOptions) *models.ChangeSet {
+	if opts.Timings == nil {
+		opts.Timings = &Timings{}
+	}
+
+	// Comparing against a snapshot without key information would report
+	// every key as added or removed, so skip the section instead
+	var warnings []string
+	if opts.Sections.Has(SectionPrimaryKeys) {
+		for _, snapshot := range []*models.SchemaSnapshot{baseline, target} {
+			if len(snapshot.Tables) > 0 && !hasPrimaryKeyInfo(snapshot) {
+				warnings = append(warnings, fmt.Sprintf(
+					"snapshot %s has no primary key information; primary key changes not compared", snapshot.Key))
+			}
+		}
+		if len(warnings) > 0 {
+			opts.Sections = opts.Sections.Without(SectionPrimaryKeys)
+		}
+	}
+
 	changeSet := &models.ChangeSet{
-		Summary: models.ChangeSummary{},
+		Summary:  models.ChangeSummary{},
+		Sections: opts.Sections.List(),
+		Warnings: warnings,
 	}
 
-	baselineTables := make(map[string]models.Table)
+	start := time.Now()
+	baselineTables := make(map[string]models.Table, len(baseline.Tables))
 	for _, table := range baseline.Tables {
 		baselineTables[table.Name] = table
 	}
 
-	targetTables := make(map[string]models.Table)
+	targetTables := make(map[string]models.Table, len(target.Tables))
 	for _, table := range target.Tables {
 		targetTables[table.Name] = table
 	}
+	opts.Timings.IndexBuild += time.Since(start)
+	opts.Timings.Tables = len(baselineTables) + len(targetTables)
 
 	for _, targetTable := range target.Tables {
 		if baselineTable, exists := baselineTables[targetTable.Name]; exists {
-			diff := compareTables(baselineTable, targetTable)
+			diff := compareTables(baselineTable, targetTable, opts)
+			if diff.ColumnsSkipped {
+				changeSet.Warnings = append(changeSet.Warnings, fmt.Sprintf(
+					"table %s has %d → %d columns (limit %d); column changes not itemized",
+					targetTable.Name, len(baselineTable.Columns), len(targetTable.Columns), opts.MaxColumns))
+			}
 			if hasChanges(diff) {
 				changeSet.TablesModified = append(changeSet.TablesModified, diff)
 				changeSet.Summary.TablesModified++
+				if diff.PrimaryKeyChange != nil {
+					changeSet.Summary.PrimaryKeysChanged++
+				}
 			}
 		} else {
 			changeSet.TablesAdded = append(changeSet.TablesAdded, targetTable)
@@ -46,18 +169,179 @@ func CompareSnapshots(baseline, target *models.SchemaSnapshot) *models.ChangeSet
 	return changeSet
 }
 
-func compareTables(baseline, target models.Table) models.TableDiff {
+func (o CompareOptions) exceedsMaxColumns(baseline, target models.Table) bool {
+	return o.MaxColumns > 0 && (len(baseline.Columns) > o.MaxColumns || len(target.Columns) > o.MaxColumns)
+}
+
+func compareTables(baseline, target models.Table, opts CompareOptions) models.TableDiff {
 	diff := models.TableDiff{
 		Name: baseline.Name,
 	}
 
-	// Compare columns
-	baselineColumns := make(map[string]models.Column)
+	if opts.Sections.Has(SectionPrimaryKeys) {
+		before := primaryKeyColumns(baseline)
+		after := primaryKeyColumns(target)
+		if !slices.Equal(before, after) {
+			diff.PrimaryKeyChange = &models.PrimaryKeyDiff{
+				Before: before,
+				After:  after,
+			}
+		}
+	}
+
+	if opts.Sections.Has(SectionColumns) {
+		start := time.Now()
+		if opts.exceedsMaxColumns(baseline, target) {
+			// Only report whether the column list changed at all
+			diff.ColumnsSkipped = !reflect.DeepEqual(baseline.Columns, target.Columns)
+			opts.Timings.ColumnsNotItemized += len(baseline.Columns) + len(target.Columns)
+		} else {
+			compareColumns(&diff, baseline, target)
+			opts.Timings.ColumnsCompared += len(baseline.Columns) + len(target.Columns)
+		}
+		opts.Timings.Columns += time.Since(start)
+	}
+
+	if opts.Sections.Has(SectionIndexes) {
+		start := time.Now()
+		compareIndexes(&diff, baseline, target)
+		opts.Timings.Indexes += time.Since(start)
+	}
+
+	if opts.Sections.Has(SectionForeignKeys) {
+		start := time.Now()
+		compareForeignKeys(&diff, baseline, target)
+		opts.Timings.ForeignKeys += time.Since(start)
+	}
+
+	// Compare row counts
+	if opts.Sections.Has(SectionRowCounts) {
+		start := time.Now()
+		if baseline.RowCount != target.RowCount {
+			change := target.RowCount - baseline.RowCount
+			diff.RowCountChange = &change
+		}
+		opts.Timings.RowCounts += time.Since(start)
+	}
+
+	// Compare checksums
+	if opts.Sections.Has(SectionChecksums) {
+		start := time.Now()
+		if baseline.Checksum != "" && target.Checksum != "" {
+			if baseline.Checksum != target.Checksum {
+				diff.ChecksumChanged = true
+			}
+		}
+		opts.Timings.Checksums += time.Since(start)
+	}
+
+	if diff.PrimaryKeyChange != nil {
+		dropPrimaryKeyDetails(&diff)
+	}
+
+	return diff
+}
+
+// hasPrimaryKeyInfo reports whether any table in the snapshot records a
+// primary key. Snapshots from drivers that didn't capture keys have none.
+func hasPrimaryKeyInfo(snapshot *models.SchemaSnapshot) bool {
+	for _, table := range snapshot.Tables {
+		if len(primaryKeyColumns(table)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryKeyColumns returns the ordered primary key columns of a table, taken
+// from its recorded primary key, its primary index or, failing both, from
+// columns keyed PRI.
+func primaryKeyColumns(table models.Table) []string {
+	if len(table.PrimaryKey) > 0 {
+		return slices.Clone(table.PrimaryKey)
+	}
+
+	for _, idx := range table.Indexes {
+		if !idx.IsPrimary {
+			continue
+		}
+		indexColumns := slices.Clone(idx.Columns)
+		sort.SliceStable(indexColumns, func(i, j int) bool {
+			return indexColumns[i].Sequence < indexColumns[j].Sequence
+		})
+		names := make([]string, len(indexColumns))
+		for i, col := range indexColumns {
+			names[i] = col.Name
+		}
+		return names
+	}
+
+	var keyColumns []models.Column
+	for _, col := range table.Columns {
+		if col.Key == "PRI" {
+			keyColumns = append(keyColumns, col)
+		}
+	}
+	sort.SliceStable(keyColumns, func(i, j int) bool {
+		return keyColumns[i].Position < keyColumns[j].Position
+	})
+	names := make([]string, len(keyColumns))
+	for i, col := range keyColumns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// dropPrimaryKeyDetails removes index and column entries that only restate a
+// primary key change, so it is reported once under its own category.
+func dropPrimaryKeyDetails(diff *models.TableDiff) {
+	diff.IndexesAdded = slices.DeleteFunc(diff.IndexesAdded, func(idx models.Index) bool {
+		return idx.IsPrimary
+	})
+	diff.IndexesRemoved = slices.DeleteFunc(diff.IndexesRemoved, func(idx models.Index) bool {
+		return idx.IsPrimary
+	})
+	diff.IndexesModified = slices.DeleteFunc(diff.IndexesModified, func(idxDiff models.IndexDiff) bool {
+		// Only the column list differs
+		before, after := idxDiff.Before, idxDiff.After
+		return before.IsPrimary && after.IsPrimary &&
+			before.IsUnique == after.IsUnique &&
+			before.Type == after.Type
+	})
+	diff.ColumnsModified = slices.DeleteFunc(diff.ColumnsModified, func(colDiff models.ColumnDiff) bool {
+		// Only the key flag differs, and it moves between PRI and no key or
+		// a plain index (MUL); a move to UNI is a real change and stays
+		before, after := colDiff.Before, colDiff.After
+		pkToggled := (before.Key == "PRI" && foldsIntoPrimaryKey(after.Key)) ||
+			(after.Key == "PRI" && foldsIntoPrimaryKey(before.Key))
+		before.Key, after.Key = "", ""
+		return pkToggled && columnsEqual(before, after)
+	})
+}
+
+func foldsIntoPrimaryKey(key string) bool {
+	return key == "" || key == "MUL"
+}
+
+// comparedSection reports whether a change set covers the given section.
+func comparedSection(changeSet *models.ChangeSet, section string) bool {
+	return len(changeSet.Sections) == 0 || slices.Contains(changeSet.Sections, section)
+}
+
+func formatKeyColumns(columns []string) string {
+	if len(columns) == 0 {
+		return "(none)"
+	}
+	return "(" + strings.Join(columns, ", ") + ")"
+}
+
+func compareColumns(diff *models.TableDiff, baseline, target models.Table) {
+	baselineColumns := make(map[string]models.Column, len(baseline.Columns))
 	for _, col := range baseline.Columns {
 		baselineColumns[col.Name] = col
 	}
 
-	targetColumns := make(map[string]models.Column)
+	targetColumns := make(map[string]models.Column, len(target.Columns))
 	for _, col := range target.Columns {
 		targetColumns[col.Name] = col
 	}
@@ -81,14 +365,15 @@ func compareTables(baseline, target models.Table) models.TableDiff {
 			diff.ColumnsRemoved = append(diff.ColumnsRemoved, baselineCol)
 		}
 	}
+}
 
-	// Compare indexes with modification detection
-	baselineIndexes := make(map[string]models.Index)
+func compareIndexes(diff *models.TableDiff, baseline, target models.Table) {
+	baselineIndexes := make(map[string]models.Index, len(baseline.Indexes))
 	for _, idx := range baseline.Indexes {
 		baselineIndexes[idx.Name] = idx
 	}
 
-	targetIndexes := make(map[string]models.Index)
+	targetIndexes := make(map[string]models.Index, len(target.Indexes))
 	for _, idx := range target.Indexes {
 		targetIndexes[idx.Name] = idx
 	}
@@ -113,14 +398,15 @@ func compareTables(baseline, target models.Table) models.TableDiff {
 			diff.IndexesRemoved = append(diff.IndexesRemoved, baselineIdx)
 		}
 	}
+}
 
-	// Compare foreign keys with modification detection
-	baselineFKs := make(map[string]models.ForeignKey)
+func compareForeignKeys(diff *models.TableDiff, baseline, target models.Table) {
+	baselineFKs := make(map[string]models.ForeignKey, len(baseline.ForeignKeys))
 	for _, fk := range baseline.ForeignKeys {
 		baselineFKs[fk.Name] = fk
 	}
 
-	targetFKs := make(map[string]models.ForeignKey)
+	targetFKs := make(map[string]models.ForeignKey, len(target.ForeignKeys))
 	for _, fk := range target.ForeignKeys {
 		targetFKs[fk.Name] = fk
 	}
@@ -145,27 +431,14 @@ func compareTables(baseline, target models.Table) models.TableDiff {
 			diff.FKRemoved = append(diff.FKRemoved, baselineFK)
 		}
 	}
-
-	// Compare row counts
-	if baseline.RowCount != target.RowCount {
-		change := target.RowCount - baseline.RowCount
-		diff.RowCountChange = &change
-	}
-
-	// Compare checksums
-	if baseline.Checksum != "" && target.Checksum != "" {
-		if baseline.Checksum != target.Checksum {
-			diff.ChecksumChanged = true
-		}
-	}
-
-	return diff
 }
 
 func hasChanges(diff models.TableDiff) bool {
-	return len(diff.ColumnsAdded) > 0 ||
+	return diff.PrimaryKeyChange != nil ||
+		len(diff.ColumnsAdded) > 0 ||
 		len(diff.ColumnsRemoved) > 0 ||
 		len(diff.ColumnsModified) > 0 ||
+		diff.ColumnsSkipped ||
 		len(diff.IndexesAdded) > 0 ||
 		len(diff.IndexesRemoved) > 0 ||
 		len(diff.IndexesModified) > 0 ||
@@ -205,75 +478,138 @@ func foreignKeysEqual(a, b models.ForeignKey) bool {
 		a.OnUpdate == b.OnUpdate
 }
 
-func FormatChangeSet(changeSet *models.ChangeSet, baselineKey, targetKey string) string {
-	output := fmt.Sprintf("=== Schema Comparison: %s → %s ===\n\n", baselineKey, targetKey)
+func FormatChangeSet(changeSet *models.ChangeSet, baselineKey, targetKey string, domains []Domain) string {
+	var output strings.Builder
+	fmt.Fprintf(&output, "=== Schema Comparison: %s → %s ===\n\n", baselineKey, targetKey)
 
-	output += "Summary:\n"
-	output += fmt.Sprintf("  Tables Added:    %d\n", changeSet.Summary.TablesAdded)
-	output += fmt.Sprintf("  Tables Removed:  %d\n", changeSet.Summary.TablesRemoved)
-	output += fmt.Sprintf("  Tables Modified: %d\n", changeSet.Summary.TablesModified)
-	output += "\n"
+	if len(changeSet.Sections) > 0 {
+		fmt.Fprintf(&output, "Sections: %s\n\n", strings.Join(changeSet.Sections, ", "))
+	}
+
+	output.WriteString("Summary:\n")
+	fmt.Fprintf(&output, "  Tables Added:    %d\n", changeSet.Summary.TablesAdded)
+	fmt.Fprintf(&output, "  Tables Removed:  %d\n", changeSet.Summary.TablesRemoved)
+	fmt.Fprintf(&output, "  Tables Modified: %d\n", changeSet.Summary.TablesModified)
+	showPrimaryKeys := comparedSection(changeSet, SectionPrimaryKeys)
+	if showPrimaryKeys {
+		fmt.Fprintf(&output, "  PK Changes:      %d\n", changeSet.Summary.PrimaryKeysChanged)
+	}
+	output.WriteString("\n")
+
+	if len(changeSet.Warnings) > 0 {
+		output.WriteString("Warnings:\n")
+		for _, warning := range changeSet.Warnings {
+			fmt.Fprintf(&output, "  ⚠ %s\n", warning)
+		}
+		output.WriteString("\n")
+	}
+
+	if len(domains) == 0 {
+		writeChanges(&output, changeSet)
+	} else {
+		for _, group := range GroupByDomain(changeSet, domains) {
+			fmt.Fprintf(&output, "=== Domain: %s ===\n", group.Name)
+			fmt.Fprintf(&output, "  Added: %d, Removed: %d, Modified: %d",
+				group.ChangeSet.Summary.TablesAdded, group.ChangeSet.Summary.TablesRemoved,
+				group.ChangeSet.Summary.TablesModified)
+			if showPrimaryKeys {
+				fmt.Fprintf(&output, ", PK Changes: %d", group.ChangeSet.Summary.PrimaryKeysChanged)
+			}
+			output.WriteString("\n\n")
+			writeChanges(&output, group.ChangeSet)
+		}
+	}
+
+	if changeSet.Summary.TablesAdded == 0 && changeSet.Summary.TablesRemoved == 0 && changeSet.Summary.TablesModified == 0 {
+		output.WriteString("No changes detected.\n")
+	}
+
+	return output.String()
+}
+
+// writeChanges renders the primary key, added, removed and modified table
+// sections of a change set.
+func writeChanges(output *strings.Builder, changeSet *models.ChangeSet) {
+	if changeSet.Summary.PrimaryKeysChanged > 0 {
+		output.WriteString("⚠ Primary Key Changes:\n")
+		for _, diff := range changeSet.TablesModified {
+			if diff.PrimaryKeyChange != nil {
+				fmt.Fprintf(output, "  ! %s: %s → %s\n", diff.Name,
+					formatKeyColumns(diff.PrimaryKeyChange.Before), formatKeyColumns(diff.PrimaryKeyChange.After))
+			}
+		}
+		output.WriteString("\n")
+	}
 
 	if len(changeSet.TablesAdded) > 0 {
-		output += "Added Tables:\n"
+		output.WriteString("Added Tables:\n")
 		for _, table := range changeSet.TablesAdded {
-			output += fmt.Sprintf("  + %s (%d columns, %d rows)\n", table.Name, len(table.Columns), table.RowCount)
+			fmt.Fprintf(output, "  + %s (%d columns, %d rows)\n", table.Name, len(table.Columns), table.RowCount)
 		}
-		output += "\n"
+		output.WriteString("\n")
 	}
 
 	if len(changeSet.TablesRemoved) > 0 {
-		output += "Removed Tables:\n"
+		output.WriteString("Removed Tables:\n")
 		for _, table := range changeSet.TablesRemoved {
-			output += fmt.Sprintf("  - %s (%d columns, %d rows)\n", table.Name, len(table.Columns), table.RowCount)
+			fmt.Fprintf(output, "  - %s (%d columns, %d rows)\n", table.Name, len(table.Columns), table.RowCount)
 		}
-		output += "\n"
+		output.WriteString("\n")
 	}
 
 	if len(changeSet.TablesModified) > 0 {
-		output += "Modified Tables:\n"
+		output.WriteString("Modified Tables:\n")
 		for _, diff := range changeSet.TablesModified {
-			output += fmt.Sprintf("  ~ %s\n", diff.Name)
+			fmt.Fprintf(output, "  ~ %s\n", diff.Name)
+
+			if diff.PrimaryKeyChange != nil {
+				fmt.Fprintf(output, "    ⚠ Primary Key Changed: %s → %s\n",
+					formatKeyColumns(diff.PrimaryKeyChange.Before), formatKeyColumns(diff.PrimaryKeyChange.After))
+			}
 
 			if len(diff.ColumnsAdded) > 0 {
-				output += "    Added Columns:\n"
+				output.WriteString("    Added Columns:\n")
 				for _, col := range diff.ColumnsAdded {
-					output += fmt.Sprintf("      + %s (%s)\n", col.Name, col.ColumnType)
+					fmt.Fprintf(output, "      + %s (%s)\n", col.Name, col.ColumnType)
 				}
 			}
 
 			if len(diff.ColumnsRemoved) > 0 {
-				output += "    Removed Columns:\n"
+				output.WriteString("    Removed Columns:\n")
 				for _, col := range diff.ColumnsRemoved {
-					output += fmt.Sprintf("      - %s (%s)\n", col.Name, col.ColumnType)
+					fmt.Fprintf(output, "      - %s (%s)\n", col.Name, col.ColumnType)
 				}
 			}
 
 			if len(diff.ColumnsModified) > 0 {
-				output += "    Modified Columns:\n"
+				output.WriteString("    Modified Columns:\n")
 				for _, colDiff := range diff.ColumnsModified {
-					output += fmt.Sprintf("      ~ %s: %s → %s\n", colDiff.Name, colDiff.Before.ColumnType, colDiff.After.ColumnType)
+					fmt.Fprintf(output, "      ~ %s: %s → %s\n", colDiff.Name, colDiff.Before.ColumnType, colDiff.After.ColumnType)
 				}
 			}
 
+			if diff.ColumnsSkipped {
+				output.WriteString("    ⚠ Columns Changed (too many columns to itemize)\n")
+			}
+
 			if len(diff.IndexesAdded) > 0 {
-				output += "    Added Indexes:\n"
+				output.WriteString("    Added Indexes:\n")
 				for _, idx := range diff.IndexesAdded {
-					output += fmt.Sprintf("      + %s\n", idx.Name)
+					fmt.Fprintf(output, "      + %s\n", idx.Name)
 				}
 			}
 
 			if len(diff.IndexesRemoved) > 0 {
-				output += "    Removed Indexes:\n"
+				output.WriteString("    Removed Indexes:\n")
 				for _, idx := range diff.IndexesRemoved {
-					output += fmt.Sprintf("      - %s\n", idx.Name)
+					fmt.Fprintf(output, "      - %s\n", idx.Name)
 				}
 			}
 
 			if len(diff.IndexesModified) > 0 {
-				output += "    Modified Indexes:\n"
+				output.WriteString("    Modified Indexes:\n")
 				for _, idxDiff := range diff.IndexesModified {
-					output += fmt.Sprintf("      ~ %s: unique=%v→%v, primary=%v→%v\n",
+					fmt.Fprintf(output, "      ~ %s: unique=%v→%v, primary=%v→%v\n",
 						idxDiff.Name,
 						idxDiff.Before.IsUnique, idxDiff.After.IsUnique,
 						idxDiff.Before.IsPrimary, idxDiff.After.IsPrimary)
@@ -281,23 +617,23 @@ func FormatChangeSet(changeSet *models.ChangeSet, baselineKey, targetKey string)
 			}
 
 			if len(diff.FKAdded) > 0 {
-				output += "    Added Foreign Keys:\n"
+				output.WriteString("    Added Foreign Keys:\n")
 				for _, fk := range diff.FKAdded {
-					output += fmt.Sprintf("      + %s → %s(%s)\n", fk.Column, fk.ReferencedTable, fk.ReferencedColumn)
+					fmt.Fprintf(output, "      + %s → %s(%s)\n", fk.Column, fk.ReferencedTable, fk.ReferencedColumn)
 				}
 			}
 
 			if len(diff.FKRemoved) > 0 {
-				output += "    Removed Foreign Keys:\n"
+				output.WriteString("    Removed Foreign Keys:\n")
 				for _, fk := range diff.FKRemoved {
-					output += fmt.Sprintf("      - %s → %s(%s)\n", fk.Column, fk.ReferencedTable, fk.ReferencedColumn)
+					fmt.Fprintf(output, "      - %s → %s(%s)\n", fk.Column, fk.ReferencedTable, fk.ReferencedColumn)
 				}
 			}
 
 			if len(diff.FKModified) > 0 {
-				output += "    Modified Foreign Keys:\n"
+				output.WriteString("    Modified Foreign Keys:\n")
 				for _, fkDiff := range diff.FKModified {
-					output += fmt.Sprintf("      ~ %s: %s(%s)→%s(%s), OnDelete:%s→%s\n",
+					fmt.Fprintf(output, "      ~ %s: %s(%s)→%s(%s), OnDelete:%s→%s\n",
 						fkDiff.Name,
 						fkDiff.Before.ReferencedTable, fkDiff.Before.ReferencedColumn,
 						fkDiff.After.ReferencedTable, fkDiff.After.ReferencedColumn,
@@ -310,34 +646,43 @@ func FormatChangeSet(changeSet *models.ChangeSet, baselineKey, targetKey string)
 				if *diff.RowCountChange < 0 {
 					sign = ""
 				}
-				output += fmt.Sprintf("    Row Count: %s%d\n", sign, *diff.RowCountChange)
+				fmt.Fprintf(output, "    Row Count: %s%d\n", sign, *diff.RowCountChange)
 			}
 
 			if diff.ChecksumChanged {
-				output += "    ⚠ Data Checksum Changed (data modified)\n"
+				output.WriteString("    ⚠ Data Checksum Changed (data modified)\n")
 			}
 
-			output += "\n"
+			output.WriteString("\n")
 		}
 	}
+}
 
-	if changeSet.Summary.TablesAdded == 0 && changeSet.Summary.TablesRemoved == 0 && changeSet.Summary.TablesModified == 0 {
-		output += "No changes detected.\n"
+func FormatChangeSetJSON(changeSet *models.ChangeSet, baselineKey, targetKey string, domains []Domain) (string, error) {
+	changes := map[string]interface{}{
+		"tables_added":    changeSet.TablesAdded,
+		"tables_removed":  changeSet.TablesRemoved,
+		"tables_modified": changeSet.TablesModified,
+	}
+	if comparedSection(changeSet, SectionPrimaryKeys) {
+		changes["primary_key_changes"] = primaryKeyChanges(changeSet)
 	}
 
-	return output
-}
-
-func FormatChangeSetJSON(changeSet *models.ChangeSet, baselineKey, targetKey string) (string, error) {
 	report := map[string]interface{}{
 		"baseline_key": baselineKey,
 		"target_key":   targetKey,
 		"summary":      changeSet.Summary,
-		"changes": map[string]interface{}{
-			"tables_added":    changeSet.TablesAdded,
-			"tables_removed":  changeSet.TablesRemoved,
-			"tables_modified": changeSet.TablesModified,
-		},
+		"changes":      changes,
+	}
+
+	if len(changeSet.Sections) > 0 {
+		report["sections"] = changeSet.Sections
+	}
+	if len(domains) > 0 {
+		report["domains"] = domainReports(changeSet, domains)
+	}
+	if len(changeSet.Warnings) > 0 {
+		report["warnings"] = changeSet.Warnings
 	}
 
 	data, err := json.MarshalIndent(report, "", "  ")
@@ -347,3 +692,56 @@ func FormatChangeSetJSON(changeSet *models.ChangeSet, baselineKey, targetKey str
 
 	return string(data), nil
 }
+
+type primaryKeyChange struct {
+	Table  string   `json:"table"`
+	Before []string `json:"before"`
+	After  []string `json:"after"`
+}
+
+func primaryKeyChanges(changeSet *models.ChangeSet) []primaryKeyChange {
+	changes := []primaryKeyChange{}
+	for _, diff := range changeSet.TablesModified {
+		if diff.PrimaryKeyChange != nil {
+			changes = append(changes, primaryKeyChange{
+				Table:  diff.Name,
+				Before: diff.PrimaryKeyChange.Before,
+				After:  diff.PrimaryKeyChange.After,
+			})
+		}
+	}
+	return changes
+}
+
+type domainReport struct {
+	Name           string               `json:"name"`
+	Summary        models.ChangeSummary `json:"summary"`
+	TablesAdded    []string             `json:"tables_added"`
+	TablesRemoved  []string             `json:"tables_removed"`
+	TablesModified []string             `json:"tables_modified"`
+}
+
+// domainReports lists table names per domain; full details stay under "changes".
+func domainReports(changeSet *models.ChangeSet, domains []Domain) []domainReport {
+	reports := []domainReport{}
+	for _, group := range GroupByDomain(changeSet, domains) {
+		report := domainReport{
+			Name:           group.Name,
+			Summary:        group.ChangeSet.Summary,
+			TablesAdded:    []string{},
+			TablesRemoved:  []string{},
+			TablesModified: []string{},
+		}
+		for _, table := range group.ChangeSet.TablesAdded {
+			report.TablesAdded = append(report.TablesAdded, table.Name)
+		}
+		for _, table := range group.ChangeSet.TablesRemoved {
+			report.TablesRemoved = append(report.TablesRemoved, table.Name)
+		}
+		for _, diff := range group.ChangeSet.TablesModified {
+			report.TablesModified = append(report.TablesModified, diff.Name)
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
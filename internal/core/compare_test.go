@@ -0,0 +1,396 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+func wideTable(name string, columns int) models.Table {
+	table := models.Table{Name: name, PrimaryKey: []string{"col_0"}}
+	for i := 0; i < columns; i++ {
+		table.Columns = append(table.Columns, models.Column{
+			Name:       fmt.Sprintf("col_%d", i),
+			Position:   i + 1,
+			ColumnType: "int",
+		})
+	}
+	return table
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	baseline := &models.SchemaSnapshot{
+		Tables: []models.Table{
+			{Name: "users", Columns: []models.Column{{Name: "id", ColumnType: "int"}}},
+			{Name: "legacy"},
+		},
+	}
+	target := &models.SchemaSnapshot{
+		Tables: []models.Table{
+			{Name: "users", Columns: []models.Column{{Name: "id", ColumnType: "bigint"}, {Name: "email", ColumnType: "varchar(255)"}}},
+			{Name: "audit_log"},
+		},
+	}
+
+	changeSet := CompareSnapshots(baseline, target)
+
+	if changeSet.Summary.TablesAdded != 1 || changeSet.Summary.TablesRemoved != 1 || changeSet.Summary.TablesModified != 1 {
+		t.Fatalf("Unexpected summary: %+v", changeSet.Summary)
+	}
+
+	diff := changeSet.TablesModified[0]
+	if len(diff.ColumnsAdded) != 1 || diff.ColumnsAdded[0].Name != "email" {
+		t.Errorf("Expected column 'email' added, got %+v", diff.ColumnsAdded)
+	}
+	if len(diff.ColumnsModified) != 1 || diff.ColumnsModified[0].Name != "id" {
+		t.Errorf("Expected column 'id' modified, got %+v", diff.ColumnsModified)
+	}
+}
+
+func TestCompareSnapshotsMaxColumns(t *testing.T) {
+	baseline := &models.SchemaSnapshot{Tables: []models.Table{wideTable("wide", 20)}}
+	target := &models.SchemaSnapshot{Tables: []models.Table{wideTable("wide", 21)}}
+
+	opts := DefaultCompareOptions()
+	opts.MaxColumns = 10
+
+	changeSet := CompareSnapshotsWithOptions(baseline, target, opts)
+
+	if len(changeSet.TablesModified) != 1 {
+		t.Fatalf("Expected 1 modified table, got %d", len(changeSet.TablesModified))
+	}
+
+	diff := changeSet.TablesModified[0]
+	if !diff.ColumnsSkipped {
+		t.Error("Expected ColumnsSkipped for table over the column limit")
+	}
+	if len(diff.ColumnsAdded) != 0 {
+		t.Errorf("Expected no itemized columns, got %d", len(diff.ColumnsAdded))
+	}
+	if len(changeSet.Warnings) != 1 {
+		t.Errorf("Expected 1 warning, got %v", changeSet.Warnings)
+	}
+
+	changeSet = CompareSnapshotsWithOptions(baseline, baseline, opts)
+	if len(changeSet.Warnings) != 0 || len(changeSet.TablesModified) != 0 {
+		t.Errorf("Expected no warning for an unchanged wide table, got %v", changeSet.Warnings)
+	}
+
+	opts.Timings = &Timings{}
+	CompareSnapshotsWithOptions(baseline, target, opts)
+	if opts.Timings.ColumnsCompared != 0 || opts.Timings.ColumnsNotItemized != 41 {
+		t.Errorf("Expected 41 columns not itemized and none compared, got %d and %d",
+			opts.Timings.ColumnsNotItemized, opts.Timings.ColumnsCompared)
+	}
+
+	opts.MaxColumns = 0
+	changeSet = CompareSnapshotsWithOptions(baseline, target, opts)
+	if len(changeSet.TablesModified[0].ColumnsAdded) != 1 {
+		t.Error("Expected itemized column diff when the limit is disabled")
+	}
+}
+
+func TestCompareSnapshotsTimings(t *testing.T) {
+	baseline := &models.SchemaSnapshot{Tables: []models.Table{wideTable("t", 3)}}
+	target := &models.SchemaSnapshot{Tables: []models.Table{wideTable("t", 4)}}
+
+	opts := DefaultCompareOptions()
+	opts.Timings = &Timings{}
+	CompareSnapshotsWithOptions(baseline, target, opts)
+
+	if opts.Timings.Tables != 2 {
+		t.Errorf("Expected 2 tables recorded, got %d", opts.Timings.Tables)
+	}
+	if opts.Timings.ColumnsCompared != 7 {
+		t.Errorf("Expected 7 columns recorded, got %d", opts.Timings.ColumnsCompared)
+	}
+	if !strings.Contains(opts.Timings.String(), "Total:") {
+		t.Error("Expected timing report to include a total")
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	sections, err := ParseSections("")
+	if err != nil || sections != nil {
+		t.Errorf("Expected nil sections for empty value, got %v (err: %v)", sections, err)
+	}
+	if !sections.Has(SectionColumns) {
+		t.Error("Expected nil sections to include every section")
+	}
+
+	sections, err = ParseSections("FKs, indexes")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(sections.List(), ",") != "indexes,fks" {
+		t.Errorf("Expected 'indexes,fks', got %v", sections.List())
+	}
+	if sections.Has(SectionColumns) {
+		t.Error("Expected columns to be excluded")
+	}
+
+	if _, err := ParseSections("indexes,views"); err == nil {
+		t.Error("Expected error for unknown section")
+	}
+
+	for _, value := range []string{",", " , "} {
+		if _, err := ParseSections(value); err == nil {
+			t.Errorf("Expected error for %q with no section names", value)
+		}
+	}
+}
+
+func TestCompareSnapshotsSections(t *testing.T) {
+	baseline := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name:     "users",
+			RowCount: 10,
+			Columns:  []models.Column{{Name: "id", ColumnType: "int"}},
+		}},
+	}
+	target := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name:     "users",
+			RowCount: 20,
+			Columns:  []models.Column{{Name: "id", ColumnType: "bigint"}},
+			Indexes:  []models.Index{{Name: "idx_users_id"}},
+		}},
+	}
+
+	opts := DefaultCompareOptions()
+	opts.Sections, _ = ParseSections("indexes")
+	changeSet := CompareSnapshotsWithOptions(baseline, target, opts)
+
+	if len(changeSet.TablesModified) != 1 {
+		t.Fatalf("Expected 1 modified table, got %d", len(changeSet.TablesModified))
+	}
+	diff := changeSet.TablesModified[0]
+	if len(diff.IndexesAdded) != 1 {
+		t.Errorf("Expected 1 index added, got %d", len(diff.IndexesAdded))
+	}
+	if len(diff.ColumnsModified) != 0 || diff.RowCountChange != nil {
+		t.Error("Expected columns and row counts to be skipped")
+	}
+
+	opts.Sections, _ = ParseSections("checksums")
+	changeSet = CompareSnapshotsWithOptions(baseline, target, opts)
+	if len(changeSet.TablesModified) != 0 {
+		t.Errorf("Expected no modified tables, got %d", len(changeSet.TablesModified))
+	}
+	if strings.Join(changeSet.Sections, ",") != "checksums" {
+		t.Errorf("Expected sections recorded on change set, got %v", changeSet.Sections)
+	}
+}
+
+func TestFormatChangeSetHidesExcludedPrimaryKeys(t *testing.T) {
+	changeSet := &models.ChangeSet{Sections: []string{SectionColumns}}
+
+	text := FormatChangeSet(changeSet, "a", "b", nil)
+	if strings.Contains(text, "PK Changes") {
+		t.Errorf("Expected no PK count in text report, got:\n%s", text)
+	}
+
+	jsonReport, err := FormatChangeSetJSON(changeSet, "a", "b", nil)
+	if err != nil {
+		t.Fatalf("FormatChangeSetJSON failed: %v", err)
+	}
+	if strings.Contains(jsonReport, "primary_key_changes") {
+		t.Errorf("Expected no primary_key_changes in JSON report, got:\n%s", jsonReport)
+	}
+
+	html, err := FormatChangeSetHTML(changeSet, "a", "b", []Domain{{Name: "core", Patterns: []string{"users"}}})
+	if err != nil {
+		t.Fatalf("FormatChangeSetHTML failed: %v", err)
+	}
+	if strings.Contains(html, "Primary Keys Changed") || strings.Contains(html, "primary key changes") {
+		t.Error("Expected no primary key summary in HTML report")
+	}
+
+	changeSet.Sections = nil
+	if !strings.Contains(FormatChangeSet(changeSet, "a", "b", nil), "PK Changes") {
+		t.Error("Expected PK count when all sections are compared")
+	}
+}
+
+func TestComparePrimaryKeyChange(t *testing.T) {
+	baseline := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name: "orders",
+			Columns: []models.Column{
+				{Name: "id", ColumnType: "int", Key: "PRI", Position: 1},
+				{Name: "tenant_id", ColumnType: "int", Position: 2},
+			},
+			Indexes: []models.Index{{
+				Name: "PRIMARY", IsPrimary: true, IsUnique: true,
+				Columns: []models.IndexColumn{{Name: "id", Sequence: 1}},
+			}},
+		}},
+	}
+	target := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name: "orders",
+			Columns: []models.Column{
+				{Name: "id", ColumnType: "int", Key: "PRI", Position: 1},
+				{Name: "tenant_id", ColumnType: "int", Key: "PRI", Position: 2},
+			},
+			Indexes: []models.Index{{
+				Name: "PRIMARY", IsPrimary: true, IsUnique: true,
+				Columns: []models.IndexColumn{{Name: "tenant_id", Sequence: 1}, {Name: "id", Sequence: 2}},
+			}},
+		}},
+	}
+
+	changeSet := CompareSnapshots(baseline, target)
+
+	if changeSet.Summary.PrimaryKeysChanged != 1 {
+		t.Fatalf("Expected 1 primary key change, got %d", changeSet.Summary.PrimaryKeysChanged)
+	}
+
+	diff := changeSet.TablesModified[0]
+	if strings.Join(diff.PrimaryKeyChange.After, ",") != "tenant_id,id" {
+		t.Errorf("Expected new key (tenant_id, id), got %v", diff.PrimaryKeyChange.After)
+	}
+	if len(diff.IndexesModified) != 0 || len(diff.ColumnsModified) != 0 {
+		t.Errorf("Expected primary key details folded into the key change, got %d index and %d column diffs",
+			len(diff.IndexesModified), len(diff.ColumnsModified))
+	}
+
+	text := FormatChangeSet(changeSet, "a", "b", nil)
+	if !strings.Contains(text, "! orders: (id) → (tenant_id, id)") {
+		t.Errorf("Expected primary key change in text output, got:\n%s", text)
+	}
+
+	if _, err := FormatChangeSetHTML(changeSet, "a", "b", nil); err != nil {
+		t.Errorf("Unexpected HTML error: %v", err)
+	}
+}
+
+func TestPrimaryKeyColumnsFromColumnKeys(t *testing.T) {
+	table := models.Table{
+		Columns: []models.Column{
+			{Name: "b", Key: "PRI", Position: 2},
+			{Name: "a", Key: "PRI", Position: 1},
+			{Name: "c", Position: 3},
+		},
+	}
+
+	if got := strings.Join(primaryKeyColumns(table), ","); got != "a,b" {
+		t.Errorf("Expected 'a,b', got '%s'", got)
+	}
+}
+
+func TestDropPrimaryKeyDetailsKeepsUnrelatedChanges(t *testing.T) {
+	baseline := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name: "accounts",
+			Columns: []models.Column{
+				{Name: "id", ColumnType: "int", Key: "PRI", Position: 1},
+				{Name: "code", ColumnType: "varchar(10)", Key: "PRI", Position: 2},
+			},
+			Indexes: []models.Index{{
+				Name: "PRIMARY", IsPrimary: true, IsUnique: true, Type: "BTREE",
+				Columns: []models.IndexColumn{{Name: "id", Sequence: 1}, {Name: "code", Sequence: 2}},
+			}},
+		}},
+	}
+	target := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name: "accounts",
+			Columns: []models.Column{
+				{Name: "id", ColumnType: "int", Key: "PRI", Position: 1},
+				{Name: "code", ColumnType: "varchar(10)", Key: "UNI", Position: 2},
+			},
+			Indexes: []models.Index{{
+				Name: "PRIMARY", IsPrimary: true, IsUnique: true, Type: "HASH",
+				Columns: []models.IndexColumn{{Name: "id", Sequence: 1}},
+			}},
+		}},
+	}
+
+	diff := CompareSnapshots(baseline, target).TablesModified[0]
+
+	if diff.PrimaryKeyChange == nil {
+		t.Fatal("Expected a primary key change")
+	}
+	if len(diff.IndexesModified) != 1 || diff.IndexesModified[0].After.Type != "HASH" {
+		t.Errorf("Expected primary index type change to be kept, got %+v", diff.IndexesModified)
+	}
+	if len(diff.ColumnsModified) != 1 || diff.ColumnsModified[0].After.Key != "UNI" {
+		t.Errorf("Expected PRI → UNI column change to be kept, got %+v", diff.ColumnsModified)
+	}
+}
+
+func TestDropPrimaryKeyDetailsFoldsIndexedColumn(t *testing.T) {
+	baseline := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name: "orders",
+			Columns: []models.Column{
+				{Name: "id", ColumnType: "int", Key: "PRI", Position: 1},
+				{Name: "tenant_id", ColumnType: "int", Key: "MUL", Position: 2},
+			},
+		}},
+	}
+	target := &models.SchemaSnapshot{
+		Tables: []models.Table{{
+			Name: "orders",
+			Columns: []models.Column{
+				{Name: "id", ColumnType: "int", Key: "PRI", Position: 1},
+				{Name: "tenant_id", ColumnType: "int", Key: "PRI", Position: 2},
+			},
+		}},
+	}
+
+	diff := CompareSnapshots(baseline, target).TablesModified[0]
+
+	if diff.PrimaryKeyChange == nil {
+		t.Fatal("Expected a primary key change")
+	}
+	if len(diff.ColumnsModified) != 0 {
+		t.Errorf("Expected MUL → PRI column change to be folded, got %+v", diff.ColumnsModified)
+	}
+}
+
+func TestComparePrimaryKeyOrderChange(t *testing.T) {
+	baseline := &models.SchemaSnapshot{
+		Tables: []models.Table{{Name: "memberships", PrimaryKey: []string{"group_id", "user_id"}}},
+	}
+	target := &models.SchemaSnapshot{
+		Tables: []models.Table{{Name: "memberships", PrimaryKey: []string{"user_id", "group_id"}}},
+	}
+
+	changeSet := CompareSnapshots(baseline, target)
+
+	if len(changeSet.TablesModified) != 1 || changeSet.TablesModified[0].PrimaryKeyChange == nil {
+		t.Fatalf("Expected a primary key change for a reordered key, got %+v", changeSet.TablesModified)
+	}
+	change := changeSet.TablesModified[0].PrimaryKeyChange
+	if !slices.Equal(change.Before, []string{"group_id", "user_id"}) || !slices.Equal(change.After, []string{"user_id", "group_id"}) {
+		t.Errorf("Unexpected primary key change %v → %v", change.Before, change.After)
+	}
+}
+
+func TestComparePrimaryKeysWithoutKeyInfo(t *testing.T) {
+	baseline := &models.SchemaSnapshot{
+		Key:    "old",
+		Tables: []models.Table{{Name: "users", Columns: []models.Column{{Name: "id", ColumnType: "int"}}}},
+	}
+	target := &models.SchemaSnapshot{
+		Key:    "new",
+		Tables: []models.Table{{Name: "users", Columns: []models.Column{{Name: "id", ColumnType: "int"}}, PrimaryKey: []string{"id"}}},
+	}
+
+	changeSet := CompareSnapshots(baseline, target)
+
+	if len(changeSet.TablesModified) != 0 {
+		t.Errorf("Expected no primary key drift against a snapshot without key info, got %+v", changeSet.TablesModified)
+	}
+	if len(changeSet.Warnings) != 1 || !strings.Contains(changeSet.Warnings[0], "old") {
+		t.Errorf("Expected a warning naming snapshot old, got %v", changeSet.Warnings)
+	}
+	if slices.Contains(changeSet.Sections, SectionPrimaryKeys) {
+		t.Errorf("Expected pks to be left out of the compared sections, got %v", changeSet.Sections)
+	}
+}
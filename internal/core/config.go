@@ -20,11 +20,13 @@ type Config struct {
 	VerifyData      bool
 	VerifyRowCounts bool
 	Workers         int
+	HashAlgorithm   string
 
 	AutoInstall bool
 	RegistryURL string
 
-	Format string
+	Format  string
+	Domains string // Table grouping for reports, e.g. "billing=billing_*;auth=auth_*"
 }
 
 func DefaultConfig() *Config {
@@ -39,6 +41,7 @@ func DefaultConfig() *Config {
 		VerifyData:      false,
 		VerifyRowCounts: true,
 		Workers:         10,
+		HashAlgorithm:   DefaultHashAlgorithm,
 		AutoInstall:     true,
 		RegistryURL:     "https://raw.githubusercontent.com/ntancardoso/dbc/main/registry/drivers.json",
 		Format:          "both",
@@ -80,15 +83,24 @@ func (c *Config) LoadFromEnv() {
 			c.Workers = workers
 		}
 	}
+	if val := os.Getenv("DBC_HASH_ALGORITHM"); val != "" {
+		c.HashAlgorithm = strings.ToLower(val)
+	}
 	if val := os.Getenv("DBC_AUTO_INSTALL"); val != "" {
 		c.AutoInstall = strings.ToLower(val) == "true"
 	}
 	if val := os.Getenv("DBC_REGISTRY_URL"); val != "" {
 		c.RegistryURL = val
 	}
+	if val := os.Getenv("DBC_DOMAINS"); val != "" {
+		c.Domains = val
+	}
 }
 
 func (c *Config) Validate() error {
+	if _, err := newContentHasher(c.HashAlgorithm); err != nil {
+		return err
+	}
 	return nil
 }
 
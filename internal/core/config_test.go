@@ -38,6 +38,10 @@ func TestDefaultConfig(t *testing.T) {
 	if !cfg.VerifyRowCounts {
 		t.Error("Expected default VerifyRowCounts true")
 	}
+
+	if cfg.HashAlgorithm != "xxhash64" {
+		t.Errorf("Expected default HashAlgorithm 'xxhash64', got '%s'", cfg.HashAlgorithm)
+	}
 }
 
 func TestLoadFromEnv(t *testing.T) {
@@ -53,6 +57,8 @@ func TestLoadFromEnv(t *testing.T) {
 	t.Setenv("DBC_VERIFY_DATA", "true")
 	t.Setenv("DBC_VERIFY_COUNTS", "false")
 	t.Setenv("DBC_AUTO_INSTALL", "false")
+	t.Setenv("DBC_HASH_ALGORITHM", "SHA256")
+	t.Setenv("DBC_DOMAINS", "billing=billing_*")
 
 	cfg := DefaultConfig()
 	cfg.LoadFromEnv()
@@ -100,6 +106,14 @@ func TestLoadFromEnv(t *testing.T) {
 	if cfg.AutoInstall {
 		t.Error("Expected AutoInstall false from env")
 	}
+
+	if cfg.HashAlgorithm != "sha256" {
+		t.Errorf("Expected HashAlgorithm 'sha256' from env, got '%s'", cfg.HashAlgorithm)
+	}
+
+	if cfg.Domains != "billing=billing_*" {
+		t.Errorf("Expected Domains 'billing=billing_*' from env, got '%s'", cfg.Domains)
+	}
 }
 
 func TestGetConnectionString(t *testing.T) {
@@ -184,3 +198,12 @@ func TestValidate(t *testing.T) {
 		t.Errorf("Expected no validation error, got: %v", err)
 	}
 }
+
+func TestValidateHashAlgorithm(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HashAlgorithm = "md5"
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for unsupported hash algorithm")
+	}
+}
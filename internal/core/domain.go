@@ -0,0 +1,125 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+// OtherDomain collects tables that match no configured domain.
+const OtherDomain = "other"
+
+type Domain struct {
+	Name     string
+	Patterns []string // Glob patterns matched against table names, e.g. billing_*
+}
+
+type DomainChanges struct {
+	Name      string
+	ChangeSet *models.ChangeSet
+}
+
+// ParseDomains parses domain definitions of the form
+// "billing=billing_*,invoice_*;auth=auth_*".
+func ParseDomains(value string) ([]Domain, error) {
+	var domains []Domain
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, patterns, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid domain definition: %s (expected name=pattern,...)", entry)
+		}
+		if name == OtherDomain {
+			return nil, fmt.Errorf("domain name %s is reserved for unmatched tables", OtherDomain)
+		}
+		if slices.ContainsFunc(domains, func(d Domain) bool { return d.Name == name }) {
+			return nil, fmt.Errorf("duplicate domain: %s", name)
+		}
+
+		domain := Domain{Name: name}
+		for _, pattern := range strings.Split(patterns, ",") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern == "" {
+				continue
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in domain %s: %w", pattern, name, err)
+			}
+			domain.Patterns = append(domain.Patterns, pattern)
+		}
+		if len(domain.Patterns) == 0 {
+			return nil, fmt.Errorf("domain %s has no patterns", name)
+		}
+
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// DomainOf returns the first domain whose patterns match the table name.
+func DomainOf(table string, domains []Domain) string {
+	for _, domain := range domains {
+		for _, pattern := range domain.Patterns {
+			if matched, _ := path.Match(pattern, table); matched {
+				return domain.Name
+			}
+		}
+	}
+	return OtherDomain
+}
+
+// GroupByDomain splits a change set into per-domain change sets with their own
+// summaries. Domains keep their configured order, followed by OtherDomain;
+// domains without changes are omitted.
+func GroupByDomain(changeSet *models.ChangeSet, domains []Domain) []DomainChanges {
+	groups := make(map[string]*models.ChangeSet)
+	group := func(table string) *models.ChangeSet {
+		name := DomainOf(table, domains)
+		if groups[name] == nil {
+			groups[name] = &models.ChangeSet{Sections: changeSet.Sections}
+		}
+		return groups[name]
+	}
+
+	for _, table := range changeSet.TablesAdded {
+		cs := group(table.Name)
+		cs.TablesAdded = append(cs.TablesAdded, table)
+		cs.Summary.TablesAdded++
+	}
+	for _, table := range changeSet.TablesRemoved {
+		cs := group(table.Name)
+		cs.TablesRemoved = append(cs.TablesRemoved, table)
+		cs.Summary.TablesRemoved++
+	}
+	for _, diff := range changeSet.TablesModified {
+		cs := group(diff.Name)
+		cs.TablesModified = append(cs.TablesModified, diff)
+		cs.Summary.TablesModified++
+		if diff.PrimaryKeyChange != nil {
+			cs.Summary.PrimaryKeysChanged++
+		}
+	}
+
+	names := make([]string, 0, len(domains)+1)
+	for _, domain := range domains {
+		names = append(names, domain.Name)
+	}
+	names = append(names, OtherDomain)
+
+	var result []DomainChanges
+	for _, name := range names {
+		if cs, ok := groups[name]; ok {
+			result = append(result, DomainChanges{Name: name, ChangeSet: cs})
+			delete(groups, name)
+		}
+	}
+	return result
+}
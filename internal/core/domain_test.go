@@ -0,0 +1,89 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+func TestParseDomains(t *testing.T) {
+	domains, err := ParseDomains("billing=billing_*, invoice_*; auth=auth_*")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(domains) != 2 {
+		t.Fatalf("Expected 2 domains, got %d", len(domains))
+	}
+	if domains[0].Name != "billing" || strings.Join(domains[0].Patterns, ",") != "billing_*,invoice_*" {
+		t.Errorf("Unexpected billing domain: %+v", domains[0])
+	}
+
+	invalid := []string{"billing", "=billing_*", "billing=", "billing=[a", "other=o_*", "auth=auth_*;auth=user_*"}
+	for _, value := range invalid {
+		if _, err := ParseDomains(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestGroupByDomain(t *testing.T) {
+	domains, _ := ParseDomains("billing=billing_*,invoice_*;auth=auth_*")
+	changeSet := &models.ChangeSet{
+		TablesAdded:   []models.Table{{Name: "invoice_lines"}, {Name: "audit_log"}},
+		TablesRemoved: []models.Table{{Name: "auth_tokens"}},
+		TablesModified: []models.TableDiff{
+			{Name: "billing_accounts", PrimaryKeyChange: &models.PrimaryKeyDiff{}},
+		},
+	}
+
+	groups := GroupByDomain(changeSet, domains)
+
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	if strings.Join(names, ",") != "billing,auth,other" {
+		t.Fatalf("Expected groups 'billing,auth,other', got %v", names)
+	}
+
+	billing := groups[0].ChangeSet.Summary
+	if billing.TablesAdded != 1 || billing.TablesModified != 1 || billing.PrimaryKeysChanged != 1 {
+		t.Errorf("Unexpected billing summary: %+v", billing)
+	}
+	if groups[2].ChangeSet.TablesAdded[0].Name != "audit_log" {
+		t.Errorf("Expected audit_log in other domain")
+	}
+
+	text := FormatChangeSet(changeSet, "a", "b", domains)
+	if !strings.Contains(text, "=== Domain: auth ===") {
+		t.Errorf("Expected domain heading in text output, got:\n%s", text)
+	}
+
+	jsonOutput, err := FormatChangeSetJSON(changeSet, "a", "b", domains)
+	if err != nil {
+		t.Fatalf("Unexpected JSON error: %v", err)
+	}
+	var report struct {
+		Domains []struct {
+			Name        string   `json:"name"`
+			TablesAdded []string `json:"tables_added"`
+		} `json:"domains"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &report); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(report.Domains) != 3 || report.Domains[0].TablesAdded[0] != "invoice_lines" {
+		t.Errorf("Unexpected JSON domains: %+v", report.Domains)
+	}
+
+	html, err := FormatChangeSetHTML(changeSet, "a", "b", domains)
+	if err != nil {
+		t.Fatalf("Unexpected HTML error: %v", err)
+	}
+	if !strings.Contains(html, "Domain: billing") {
+		t.Error("Expected domain section in HTML output")
+	}
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"sort"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+const (
+	HashXXHash64 = "xxhash64"
+	HashSHA256   = "sha256"
+
+	DefaultHashAlgorithm = HashXXHash64
+)
+
+func newContentHasher(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case HashXXHash64:
+		return xxhash.New(), nil
+	case HashSHA256:
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s (use %s or %s)", algorithm, HashXXHash64, HashSHA256)
+	}
+}
+
+// tableContent is the part of a table that defines its content. Estimated
+// statistics (row count, data length, create/update times) vary between
+// captures of an unchanged table and are left out.
+type tableContent struct {
+	Name          string              `json:"name"`
+	Engine        string              `json:"engine,omitempty"`
+	Collation     string              `json:"collation,omitempty"`
+	PrimaryKey    []string            `json:"primary_key,omitempty"`
+	Columns       []models.Column     `json:"columns"`
+	Indexes       []models.Index      `json:"indexes"`
+	ForeignKeys   []models.ForeignKey `json:"foreign_keys"`
+	Constraints   []models.Constraint `json:"constraints"`
+	Checksum      string              `json:"checksum,omitempty"`
+	ExactRowCount *int64              `json:"exact_row_count,omitempty"`
+}
+
+// TableContentHash returns the content address of a table as "<algorithm>:<hex>".
+// Identical tables hash identically regardless of which snapshot they belong to.
+func TableContentHash(table models.Table, algorithm string) (string, error) {
+	h, err := newContentHasher(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(tableContent{
+		Name:          table.Name,
+		Engine:        table.Engine,
+		Collation:     table.Collation,
+		PrimaryKey:    table.PrimaryKey,
+		Columns:       table.Columns,
+		Indexes:       table.Indexes,
+		ForeignKeys:   table.ForeignKeys,
+		Constraints:   table.Constraints,
+		Checksum:      table.Checksum,
+		ExactRowCount: table.ExactRowCount,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal table %s: %w", table.Name, err)
+	}
+	h.Write(data)
+
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SnapshotContentHash combines the table hashes of a snapshot. Key, host and
+// timestamp are excluded so two captures of the same schema share a hash.
+func SnapshotContentHash(snapshot *models.SchemaSnapshot, algorithm string) (string, error) {
+	h, err := newContentHasher(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	tables := make([]models.Table, len(snapshot.Tables))
+	copy(tables, snapshot.Tables)
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+
+	for _, table := range tables {
+		tableHash, err := TableContentHash(table, algorithm)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%s\n", table.Name, tableHash)
+	}
+
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
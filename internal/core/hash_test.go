@@ -0,0 +1,75 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+func TestTableContentHash(t *testing.T) {
+	users := models.Table{Name: "users", Columns: []models.Column{{Name: "id", ColumnType: "int"}}}
+	changed := models.Table{Name: "users", Columns: []models.Column{{Name: "id", ColumnType: "bigint"}}}
+
+	for _, algorithm := range []string{HashXXHash64, HashSHA256} {
+		t.Run(algorithm, func(t *testing.T) {
+			h1, err := TableContentHash(users, algorithm)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			h2, _ := TableContentHash(users, algorithm)
+			h3, _ := TableContentHash(changed, algorithm)
+
+			if !strings.HasPrefix(h1, algorithm+":") {
+				t.Errorf("Expected hash prefixed with '%s:', got '%s'", algorithm, h1)
+			}
+			if h1 != h2 {
+				t.Error("Expected identical tables to hash identically")
+			}
+			if h1 == h3 {
+				t.Error("Expected different tables to hash differently")
+			}
+		})
+	}
+
+	if _, err := TableContentHash(users, "md5"); err == nil {
+		t.Error("Expected error for unsupported algorithm")
+	}
+}
+
+func TestTableContentHashIgnoresStatistics(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	first := models.Table{Name: "users", RowCount: 100, DataLength: 16384, CreateTime: &created, UpdateTime: &created}
+	second := models.Table{Name: "users", RowCount: 104, DataLength: 32768, CreateTime: &created, UpdateTime: &updated}
+
+	h1, _ := TableContentHash(first, DefaultHashAlgorithm)
+	h2, _ := TableContentHash(second, DefaultHashAlgorithm)
+	if h1 != h2 {
+		t.Error("Expected tables differing only in statistics to hash identically")
+	}
+
+	exact := int64(100)
+	second.ExactRowCount = &exact
+	h3, _ := TableContentHash(second, DefaultHashAlgorithm)
+	if h1 == h3 {
+		t.Error("Expected exact row count to be part of the hash")
+	}
+}
+
+func TestSnapshotContentHash(t *testing.T) {
+	a := &models.SchemaSnapshot{Key: "a", Tables: []models.Table{{Name: "users"}, {Name: "orders"}}}
+	b := &models.SchemaSnapshot{Key: "b", Tables: []models.Table{{Name: "orders"}, {Name: "users"}}}
+
+	h1, err := SnapshotContentHash(a, DefaultHashAlgorithm)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	h2, _ := SnapshotContentHash(b, DefaultHashAlgorithm)
+
+	if h1 != h2 {
+		t.Error("Expected snapshot hash to ignore key and table order")
+	}
+}
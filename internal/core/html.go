@@ -3,25 +3,69 @@ package core
 import (
 	"bytes"
 	"html/template"
+	"strings"
 
 	"github.com/ntancardoso/dbc/internal/models"
 )
 
 type TableDiffView struct {
-	Name            string
-	ColumnsAdded    []models.Column
-	ColumnsRemoved  []models.Column
-	ColumnsModified []models.ColumnDiff
-	IndexesAdded    []models.Index
-	IndexesRemoved  []models.Index
-	FKAdded         []models.ForeignKey
-	FKRemoved       []models.ForeignKey
-	RowCountChange  *int64
-	ChecksumChanged bool
+	Name             string
+	PrimaryKeyChange *models.PrimaryKeyDiff
+	ColumnsAdded     []models.Column
+	ColumnsRemoved   []models.Column
+	ColumnsModified  []models.ColumnDiff
+	ColumnsSkipped   bool
+	IndexesAdded     []models.Index
+	IndexesRemoved   []models.Index
+	FKAdded          []models.ForeignKey
+	FKRemoved        []models.ForeignKey
+	RowCountChange   *int64
+	ChecksumChanged  bool
 }
 
-func FormatChangeSetHTML(changeSet *models.ChangeSet, baselineKey, targetKey string) (string, error) {
+type ChangesView struct {
+	Summary        models.ChangeSummary
+	TablesAdded    []models.Table
+	TablesRemoved  []models.Table
+	TablesModified []TableDiffView
+}
+
+type DomainView struct {
+	Name    string
+	Changes ChangesView
+}
+
+func newChangesView(changeSet *models.ChangeSet) ChangesView {
+	modifiedViews := make([]TableDiffView, len(changeSet.TablesModified))
+	for i, diff := range changeSet.TablesModified {
+		modifiedViews[i] = TableDiffView{
+			Name:             diff.Name,
+			PrimaryKeyChange: diff.PrimaryKeyChange,
+			ColumnsAdded:     diff.ColumnsAdded,
+			ColumnsRemoved:   diff.ColumnsRemoved,
+			ColumnsModified:  diff.ColumnsModified,
+			ColumnsSkipped:   diff.ColumnsSkipped,
+			IndexesAdded:     diff.IndexesAdded,
+			IndexesRemoved:   diff.IndexesRemoved,
+			FKAdded:          diff.FKAdded,
+			FKRemoved:        diff.FKRemoved,
+			RowCountChange:   diff.RowCountChange,
+			ChecksumChanged:  diff.ChecksumChanged,
+		}
+	}
+
+	return ChangesView{
+		Summary:        changeSet.Summary,
+		TablesAdded:    changeSet.TablesAdded,
+		TablesRemoved:  changeSet.TablesRemoved,
+		TablesModified: modifiedViews,
+	}
+}
+
+func FormatChangeSetHTML(changeSet *models.ChangeSet, baselineKey, targetKey string, domains []Domain) (string, error) {
 	funcMap := template.FuncMap{
+		"join":    strings.Join,
+		"keycols": formatKeyColumns,
 		"deref": func(p *int64) int64 {
 			if p == nil {
 				return 0
@@ -35,36 +79,33 @@ func FormatChangeSetHTML(changeSet *models.ChangeSet, baselineKey, targetKey str
 		return "", err
 	}
 
-	modifiedViews := make([]TableDiffView, len(changeSet.TablesModified))
-	for i, diff := range changeSet.TablesModified {
-		modifiedViews[i] = TableDiffView{
-			Name:            diff.Name,
-			ColumnsAdded:    diff.ColumnsAdded,
-			ColumnsRemoved:  diff.ColumnsRemoved,
-			ColumnsModified: diff.ColumnsModified,
-			IndexesAdded:    diff.IndexesAdded,
-			IndexesRemoved:  diff.IndexesRemoved,
-			FKAdded:         diff.FKAdded,
-			FKRemoved:       diff.FKRemoved,
-			RowCountChange:  diff.RowCountChange,
-			ChecksumChanged: diff.ChecksumChanged,
-		}
-	}
-
 	data := struct {
-		BaselineKey    string
-		TargetKey      string
-		Summary        models.ChangeSummary
-		TablesAdded    []models.Table
-		TablesRemoved  []models.Table
-		TablesModified []TableDiffView
+		BaselineKey string
+		TargetKey   string
+		Summary     models.ChangeSummary
+		ShowPKs     bool
+		Sections    []string
+		Warnings    []string
+		Changes     ChangesView
+		Domains     []DomainView
 	}{
-		BaselineKey:    baselineKey,
-		TargetKey:      targetKey,
-		Summary:        changeSet.Summary,
-		TablesAdded:    changeSet.TablesAdded,
-		TablesRemoved:  changeSet.TablesRemoved,
-		TablesModified: modifiedViews,
+		BaselineKey: baselineKey,
+		TargetKey:   targetKey,
+		Summary:     changeSet.Summary,
+		ShowPKs:     comparedSection(changeSet, SectionPrimaryKeys),
+		Sections:    changeSet.Sections,
+		Warnings:    changeSet.Warnings,
+	}
+
+	if len(domains) == 0 {
+		data.Changes = newChangesView(changeSet)
+	} else {
+		for _, group := range GroupByDomain(changeSet, domains) {
+			data.Domains = append(data.Domains, DomainView{
+				Name:    group.Name,
+				Changes: newChangesView(group.ChangeSet),
+			})
+		}
 	}
 
 	var buf bytes.Buffer
@@ -20,6 +20,7 @@ const htmlTemplate = `<!DOCTYPE html>
         .added .number { color: #10b981; }
         .removed .number { color: #ef4444; }
         .modified .number { color: #f59e0b; }
+        .pk .number { color: #dc2626; }
         .content { padding: 30px; }
         .section { margin-bottom: 30px; }
         .section h2 { font-size: 20px; margin-bottom: 15px; padding-bottom: 10px; border-bottom: 2px solid #e5e7eb; }
@@ -27,6 +28,7 @@ const htmlTemplate = `<!DOCTYPE html>
         .table-item.added { border-left-color: #10b981; background: #ecfdf5; }
         .table-item.removed { border-left-color: #ef4444; background: #fef2f2; }
         .table-item.modified { border-left-color: #f59e0b; background: #fffbeb; }
+        .table-item.pk { border-left-color: #dc2626; background: #fef2f2; }
         .table-name { font-weight: 600; font-size: 16px; margin-bottom: 8px; }
         .table-meta { font-size: 14px; color: #666; }
         .change-list { margin-top: 10px; padding-left: 20px; }
@@ -36,6 +38,9 @@ const htmlTemplate = `<!DOCTYPE html>
         .change-item.modify { color: #f59e0b; }
         .change-item.warning { color: #dc2626; font-weight: 500; }
         .icon { margin-right: 8px; }
+        .domain { margin-bottom: 40px; }
+        .domain-header { margin-bottom: 20px; padding: 15px; background: #eef2ff; border-radius: 6px; }
+        .domain-header h2 { font-size: 22px; margin-bottom: 4px; }
         .no-changes { text-align: center; padding: 60px 20px; color: #9ca3af; }
         .no-changes .icon { font-size: 48px; margin-bottom: 15px; }
     </style>
@@ -45,6 +50,7 @@ const htmlTemplate = `<!DOCTYPE html>
         <div class="header">
             <h1>Database Schema Comparison</h1>
             <div class="comparison">{{.BaselineKey}} → {{.TargetKey}}</div>
+            {{if .Sections}}<div class="comparison">Sections: {{join .Sections ", "}}</div>{{end}}
         </div>
 
         <div class="summary">
@@ -60,9 +66,62 @@ const htmlTemplate = `<!DOCTYPE html>
                 <div class="number">{{.Summary.TablesModified}}</div>
                 <div class="label">Tables Modified</div>
             </div>
+            {{if .ShowPKs}}
+            <div class="summary-card pk">
+                <div class="number">{{.Summary.PrimaryKeysChanged}}</div>
+                <div class="label">Primary Keys Changed</div>
+            </div>
+            {{end}}
         </div>
 
         <div class="content">
+            {{if .Warnings}}
+            <div class="section">
+                <h2>Warnings</h2>
+                {{range .Warnings}}
+                <div class="change-item warning"><span class="icon">⚠</span>{{.}}</div>
+                {{end}}
+            </div>
+            {{end}}
+
+            {{if .Domains}}
+            {{range .Domains}}
+            <div class="domain">
+                <div class="domain-header">
+                    <h2>Domain: {{.Name}}</h2>
+                    <div class="table-meta">{{.Changes.Summary.TablesAdded}} added, {{.Changes.Summary.TablesRemoved}} removed, {{.Changes.Summary.TablesModified}} modified{{if $.ShowPKs}}, {{.Changes.Summary.PrimaryKeysChanged}} primary key changes{{end}}</div>
+                </div>
+                {{template "changes" .Changes}}
+            </div>
+            {{end}}
+            {{else}}
+            {{template "changes" .Changes}}
+            {{end}}
+
+            {{if and (eq .Summary.TablesAdded 0) (eq .Summary.TablesRemoved 0) (eq .Summary.TablesModified 0)}}
+            <div class="no-changes">
+                <div class="icon">✓</div>
+                <div>No changes detected</div>
+            </div>
+            {{end}}
+        </div>
+    </div>
+</body>
+</html>
+
+{{define "changes"}}
+            {{if .Summary.PrimaryKeysChanged}}
+            <div class="section">
+                <h2>⚠ Primary Key Changes</h2>
+                {{range .TablesModified}}{{if .PrimaryKeyChange}}
+                <div class="table-item pk">
+                    <div class="table-name">! {{.Name}}</div>
+                    <div class="table-meta">{{keycols .PrimaryKeyChange.Before}} → {{keycols .PrimaryKeyChange.After}}</div>
+                </div>
+                {{end}}{{end}}
+            </div>
+            {{end}}
+
             {{if .TablesAdded}}
             <div class="section">
                 <h2>Added Tables</h2>
@@ -94,6 +153,9 @@ const htmlTemplate = `<!DOCTYPE html>
                 <div class="table-item modified">
                     <div class="table-name">~ {{.Name}}</div>
                     <div class="change-list">
+                        {{if .PrimaryKeyChange}}
+                        <div class="change-item warning"><span class="icon">⚠</span>Primary Key: {{keycols .PrimaryKeyChange.Before}} → {{keycols .PrimaryKeyChange.After}}</div>
+                        {{end}}
                         {{range .ColumnsAdded}}
                         <div class="change-item add"><span class="icon">+</span>Column: {{.Name}} ({{.ColumnType}})</div>
                         {{end}}
@@ -103,6 +165,9 @@ const htmlTemplate = `<!DOCTYPE html>
                         {{range .ColumnsModified}}
                         <div class="change-item modify"><span class="icon">~</span>Column: {{.Name}} ({{.Before.ColumnType}} → {{.After.ColumnType}})</div>
                         {{end}}
+                        {{if .ColumnsSkipped}}
+                        <div class="change-item warning"><span class="icon">⚠</span>Columns Changed (too many columns to itemize)</div>
+                        {{end}}
                         {{range .IndexesAdded}}
                         <div class="change-item add"><span class="icon">+</span>Index: {{.Name}}</div>
                         {{end}}
@@ -126,14 +191,4 @@ const htmlTemplate = `<!DOCTYPE html>
                 {{end}}
             </div>
             {{end}}
-
-            {{if and (eq .Summary.TablesAdded 0) (eq .Summary.TablesRemoved 0) (eq .Summary.TablesModified 0)}}
-            <div class="no-changes">
-                <div class="icon">✓</div>
-                <div>No changes detected</div>
-            </div>
-            {{end}}
-        </div>
-    </div>
-</body>
-</html>`
+{{end}}`
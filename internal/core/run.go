@@ -1,13 +1,18 @@
 package core
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/ntancardoso/dbc/internal/db"
+	"github.com/ntancardoso/dbc/internal/models"
 )
 
 const version = "0.1.0"
@@ -33,6 +38,8 @@ func Run(args []string) error {
 		return runShow(args[2:])
 	case "driver":
 		return runDriver(args[2:])
+	case "cache":
+		return runCache(args[2:])
 	case "version", "--version", "-v":
 		fmt.Printf("dbc version %s\n", version)
 		return nil
@@ -147,24 +154,18 @@ func runCapture(args []string) error {
 }
 
 func runCompare(args []string) error {
-	var positionalArgs []string
-	var flagArgs []string
-
-	for i := 0; i < len(args); i++ {
-		if strings.HasPrefix(args[i], "-") {
-			flagArgs = append(flagArgs, args[i])
-			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
-				flagArgs = append(flagArgs, args[i+1])
-				i++
-			}
-		} else {
-			positionalArgs = append(positionalArgs, args[i])
-		}
-	}
-
 	fs := flag.NewFlagSet("compare", flag.ExitOnError)
-	outputDir := fs.String("output", "", "Snapshot directory")
-	format := fs.String("format", "text", "Output format (text, json, html)")
+	outputDir := fs.String("output", "", "Snapshot directory to read snapshots from (not the report directory, see -o)")
+	format := fs.String("format", "text", "Output format(s), comma-separated (text, json, html)")
+	reportDir := fs.String("o", "", "Report directory to write each format to (not the snapshot directory, see --output)")
+	timings := fs.Bool("timings", false, "Print a timing breakdown to stderr")
+	maxColumns := fs.Int("max-columns", DefaultMaxColumns, "Skip column-level diff for tables with more columns (0 = unlimited)")
+	domainsFlag := fs.String("domains", "", "Group report by domain, e.g. billing=billing_*;auth=auth_*")
+	only := fs.String("only", "", "Compare only these sections (pks, columns, indexes, fks, rowcounts, checksums)")
+	useCache := fs.Bool("cache", false, "Reuse a cached comparison of the same snapshot pair, caching the result on a miss")
+	fromCache := fs.Bool("from-cache", false, "Render a cached comparison only; fail instead of recomputing")
+
+	flagArgs, positionalArgs := splitArgs(fs, args)
 	if err := fs.Parse(flagArgs); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
@@ -176,49 +177,209 @@ func runCompare(args []string) error {
 	key1 := positionalArgs[0]
 	key2 := positionalArgs[1]
 
+	formats, err := parseFormats(*format)
+	if err != nil {
+		return err
+	}
+	if len(formats) > 1 && *reportDir == "" {
+		return fmt.Errorf("multiple formats require an output directory (-o)")
+	}
+
+	sections, err := ParseSections(*only)
+	if err != nil {
+		return err
+	}
+
 	cfg := DefaultConfig()
 	cfg.LoadFromEnv()
 	if *outputDir != "" {
 		cfg.OutputDir = *outputDir
 	}
+	if *domainsFlag != "" {
+		cfg.Domains = *domainsFlag
+	}
+
+	domains, err := ParseDomains(cfg.Domains)
+	if err != nil {
+		return err
+	}
+
+	if *reportDir != "" && sameDir(*reportDir, cfg.OutputDir) {
+		return fmt.Errorf("report directory (-o) must differ from the snapshot directory (--output): %s", cfg.OutputDir)
+	}
 
 	storage := NewSnapshotStorage(cfg.OutputDir)
 
+	opts := DefaultCompareOptions()
+	opts.MaxColumns = *maxColumns
+	opts.Sections = sections
+	opts.Timings = &Timings{}
+
 	fmt.Fprintf(os.Stderr, "Loading snapshots...\n")
-	snapshot1, err := storage.Load(key1)
+	start := time.Now()
+	snapshot1, digest1, err := storage.LoadWithDigest(key1)
 	if err != nil {
 		return fmt.Errorf("failed to load snapshot '%s': %w", key1, err)
 	}
 
-	snapshot2, err := storage.Load(key2)
+	snapshot2, digest2, err := storage.LoadWithDigest(key2)
 	if err != nil {
 		return fmt.Errorf("failed to load snapshot '%s': %w", key2, err)
 	}
+	opts.Timings.Load = time.Since(start)
+
+	var changeSet *models.ChangeSet
+	if *useCache || *fromCache {
+		cache := NewCompareCache(CompareCacheDir(cfg.OutputDir))
+		changeSet, err = compareCached(cache, snapshot1, snapshot2, CompareCacheKey(digest1, digest2, opts), opts, *fromCache)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Comparing: %s → %s\n\n", key1, key2)
+		changeSet = CompareSnapshotsWithOptions(snapshot1, snapshot2, opts)
+	}
+
+	start = time.Now()
+	reports := make(map[string]string, len(formats))
+	for _, f := range formats {
+		report, err := formatReport(f, changeSet, key1, key2, domains)
+		if err != nil {
+			return err
+		}
+		reports[f] = report
+	}
+	opts.Timings.Format = time.Since(start)
+
+	if *reportDir == "" {
+		fmt.Println(reports[formats[0]])
+	} else {
+		if err := os.MkdirAll(*reportDir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+		for _, f := range formats {
+			path := filepath.Join(*reportDir, fmt.Sprintf("%s_vs_%s.%s", key1, key2, reportExtensions[f]))
+			if err := os.WriteFile(path, []byte(reports[f]), 0644); err != nil {
+				return fmt.Errorf("failed to write %s report: %w", f, err)
+			}
+			fmt.Fprintf(os.Stderr, "✓ Wrote %s\n", path)
+		}
+	}
 
-	fmt.Fprintf(os.Stderr, "Comparing: %s → %s\n\n", key1, key2)
-	changeSet := CompareSnapshots(snapshot1, snapshot2)
+	if *timings {
+		fmt.Fprint(os.Stderr, opts.Timings.String())
+	}
+
+	return nil
+}
+
+// sameDir reports whether two paths name the same directory.
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
+
+var reportExtensions = map[string]string{
+	"text": "txt",
+	"json": "json",
+	"html": "html",
+}
+
+// parseFormats parses a comma-separated format list such as "text,json,html".
+func parseFormats(value string) ([]string, error) {
+	var formats []string
+	for _, f := range strings.Split(value, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || slices.Contains(formats, f) {
+			continue
+		}
+		if _, ok := reportExtensions[f]; !ok {
+			return nil, fmt.Errorf("unknown format: %s (use text, json, html)", f)
+		}
+		formats = append(formats, f)
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no output format given")
+	}
+	return formats, nil
+}
 
-	var output string
-	switch *format {
+func formatReport(format string, changeSet *models.ChangeSet, baselineKey, targetKey string, domains []Domain) (string, error) {
+	switch format {
 	case "json":
-		jsonOutput, err := FormatChangeSetJSON(changeSet, key1, key2)
+		jsonOutput, err := FormatChangeSetJSON(changeSet, baselineKey, targetKey, domains)
 		if err != nil {
-			return fmt.Errorf("failed to format JSON: %w", err)
+			return "", fmt.Errorf("failed to format JSON: %w", err)
 		}
-		output = jsonOutput
+		return jsonOutput, nil
 	case "html":
-		htmlOutput, err := FormatChangeSetHTML(changeSet, key1, key2)
+		htmlOutput, err := FormatChangeSetHTML(changeSet, baselineKey, targetKey, domains)
 		if err != nil {
-			return fmt.Errorf("failed to format HTML: %w", err)
+			return "", fmt.Errorf("failed to format HTML: %w", err)
 		}
-		output = htmlOutput
+		return htmlOutput, nil
 	default:
-		output = FormatChangeSet(changeSet, key1, key2)
+		return FormatChangeSet(changeSet, baselineKey, targetKey, domains), nil
 	}
+}
 
-	fmt.Println(output)
+// compareCached returns the cached ChangeSet for the snapshot pair, computing
+// and storing it on a miss unless fromCache demands an existing entry.
+func compareCached(cache *CompareCache, baseline, target *models.SchemaSnapshot, cacheKey string, opts CompareOptions, fromCache bool) (*models.ChangeSet, error) {
+	changeSet, err := cache.Load(cacheKey)
+	if err == nil {
+		opts.Timings.CacheHit = true
+		fmt.Fprintf(os.Stderr, "Using cached comparison: %s → %s\n\n", baseline.Key, target.Key)
+		return changeSet, nil
+	}
+	if !errors.Is(err, ErrCacheMiss) {
+		return nil, err
+	}
+	if fromCache {
+		return nil, fmt.Errorf("no cached comparison for %s → %s (run compare with --cache first)", baseline.Key, target.Key)
+	}
 
-	return nil
+	fmt.Fprintf(os.Stderr, "Comparing: %s → %s\n\n", baseline.Key, target.Key)
+	changeSet = CompareSnapshotsWithOptions(baseline, target, opts)
+
+	if err := cache.Save(cacheKey, changeSet); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	return changeSet, nil
+}
+
+// splitArgs separates flags from positional arguments so flags may appear
+// after the snapshot keys. Boolean flags never consume the following argument.
+func splitArgs(fs *flag.FlagSet, args []string) (flagArgs, positionalArgs []string) {
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			positionalArgs = append(positionalArgs, args[i])
+			continue
+		}
+
+		flagArgs = append(flagArgs, args[i])
+
+		name := strings.TrimLeft(args[i], "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if f := fs.Lookup(name); f != nil {
+			if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+				continue
+			}
+		}
+
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			flagArgs = append(flagArgs, args[i+1])
+			i++
+		}
+	}
+	return flagArgs, positionalArgs
 }
 
 func runList(args []string) error {
@@ -265,21 +426,29 @@ func runList(args []string) error {
 func runShow(args []string) error {
 	fs := flag.NewFlagSet("show", flag.ExitOnError)
 	outputDir := fs.String("output", "", "Snapshot directory")
-	if err := fs.Parse(args); err != nil {
+	hashAlgorithm := fs.String("hash", "", "Content hash algorithm (xxhash64, sha256)")
+	flagArgs, positionalArgs := splitArgs(fs, args)
+	if err := fs.Parse(flagArgs); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
 
-	if fs.NArg() < 1 {
+	if len(positionalArgs) < 1 {
 		return fmt.Errorf("show requires a snapshot key")
 	}
 
-	key := fs.Arg(0)
+	key := positionalArgs[0]
 
 	cfg := DefaultConfig()
 	cfg.LoadFromEnv()
 	if *outputDir != "" {
 		cfg.OutputDir = *outputDir
 	}
+	if *hashAlgorithm != "" {
+		cfg.HashAlgorithm = strings.ToLower(*hashAlgorithm)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
 
 	storage := NewSnapshotStorage(cfg.OutputDir)
 
@@ -288,14 +457,26 @@ func runShow(args []string) error {
 		return fmt.Errorf("failed to load snapshot: %w", err)
 	}
 
+	snapshotHash, err := SnapshotContentHash(snapshot, cfg.HashAlgorithm)
+	if err != nil {
+		return fmt.Errorf("failed to hash snapshot: %w", err)
+	}
+
 	fmt.Printf("=== Snapshot: %s ===\n\n", key)
 	fmt.Printf("Database: %s\n", snapshot.Database)
 	fmt.Printf("Timestamp: %s\n", snapshot.Timestamp.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Content Hash: %s\n", snapshotHash)
 	fmt.Printf("Tables: %d\n\n", len(snapshot.Tables))
 
 	fmt.Println("Tables:")
 	for _, table := range snapshot.Tables {
+		tableHash, err := TableContentHash(table, cfg.HashAlgorithm)
+		if err != nil {
+			return fmt.Errorf("failed to hash table %s: %w", table.Name, err)
+		}
+
 		fmt.Printf("  %s\n", table.Name)
+		fmt.Printf("    Hash: %s\n", tableHash)
 		fmt.Printf("    Columns: %d\n", len(table.Columns))
 		fmt.Printf("    Indexes: %d\n", len(table.Indexes))
 		fmt.Printf("    Foreign Keys: %d\n", len(table.ForeignKeys))
@@ -305,6 +486,37 @@ func runShow(args []string) error {
 	return nil
 }
 
+func runCache(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("cache command requires a subcommand (clear)")
+	}
+
+	switch args[0] {
+	case "clear":
+		fs := flag.NewFlagSet("cache clear", flag.ExitOnError)
+		outputDir := fs.String("output", "", "Snapshot directory")
+		if err := fs.Parse(args[1:]); err != nil {
+			return fmt.Errorf("failed to parse flags: %w", err)
+		}
+
+		cfg := DefaultConfig()
+		cfg.LoadFromEnv()
+		if *outputDir != "" {
+			cfg.OutputDir = *outputDir
+		}
+
+		removed, err := NewCompareCache(CompareCacheDir(cfg.OutputDir)).Clear()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed %d cached comparison(s)\n", removed)
+		return nil
+	default:
+		return fmt.Errorf("unknown cache subcommand: %s", args[0])
+	}
+}
+
 func runDriver(args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("driver command requires a subcommand (list, install, uninstall, info)")
@@ -446,6 +658,7 @@ Commands:
   list                     List all snapshots (alias: ls)
   show <key>               Show snapshot details
   driver <subcommand>      Manage database drivers
+  cache clear              Remove cached comparison results
 
 Driver Subcommands:
   driver list              List available drivers
@@ -467,6 +680,21 @@ Capture Options:
   --verify-data            Verify data with checksums (default: false)
   --verify-counts          Get exact row counts (default: true)
 
+Compare Options:
+  --format <formats>       Output format(s): text, json, html, comma-separated (default: text)
+  -o <dir>                 Report directory; write reports there instead of stdout
+                           (required for several formats, must not be the snapshot directory)
+  --output <dir>           Snapshot directory to read from (default: ./db_snapshots)
+  --timings                Print a timing breakdown to stderr
+  --max-columns <n>        Skip column-level diff above n columns (default: 10000)
+  --only <sections>        Compare only: pks, columns, indexes, fks, rowcounts, checksums
+  --domains <spec>         Group report by domain, e.g. "billing=billing_*;auth=auth_*"
+  --cache                  Reuse or store the comparison in the cache
+  --from-cache             Render a cached comparison; fail if none exists
+
+Show Options:
+  --hash <algorithm>       Content hash algorithm: xxhash64, sha256 (default: xxhash64)
+
 Environment Variables:
   DB_TYPE                  Database type
   DB_HOST                  Database host
@@ -476,6 +704,8 @@ Environment Variables:
   DB_NAME                  Database name
   DBC_OUTPUT_DIR           Output directory
   DBC_WORKERS              Number of workers
+  DBC_HASH_ALGORITHM       Content hash algorithm (default: xxhash64)
+  DBC_DOMAINS              Report domains (same syntax as --domains)
   DBC_AUTO_INSTALL         Auto-install drivers (default: true)
 
 Examples:
@@ -491,6 +721,9 @@ Examples:
   # Compare two snapshots
   dbc compare baseline v1.2.3
 
+  # Write text, JSON and HTML reports in one run
+  dbc compare baseline v1.2.3 --format text,json,html -o reports/
+
   # List available drivers
   dbc driver list
 
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/ntancardoso/dbc/internal/models"
+)
+
+func TestSplitArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("format", "text", "")
+	fs.Bool("timings", false, "")
+
+	flagArgs, positionalArgs := splitArgs(fs, []string{"--timings", "a", "b", "--format", "json"})
+
+	if strings.Join(positionalArgs, ",") != "a,b" {
+		t.Errorf("Expected positional args 'a,b', got %v", positionalArgs)
+	}
+	if strings.Join(flagArgs, ",") != "--timings,--format,json" {
+		t.Errorf("Unexpected flag args %v", flagArgs)
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	formats, err := parseFormats("text, JSON,html,json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(formats, ",") != "text,json,html" {
+		t.Errorf("Expected 'text,json,html', got %v", formats)
+	}
+
+	for _, value := range []string{"", "xml", "text,pdf"} {
+		if _, err := parseFormats(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	changeSet := &models.ChangeSet{}
+
+	for format, marker := range map[string]string{
+		"text": "=== Schema Comparison",
+		"json": `"baseline_key"`,
+		"html": "<!DOCTYPE html>",
+	} {
+		report, err := formatReport(format, changeSet, "a", "b", nil)
+		if err != nil {
+			t.Fatalf("Unexpected %s error: %v", format, err)
+		}
+		if !strings.Contains(report, marker) {
+			t.Errorf("Expected %s report to contain %q", format, marker)
+		}
+	}
+}
+
+func TestSameDir(t *testing.T) {
+	if !sameDir("./db_snapshots", "db_snapshots/") {
+		t.Error("Expected equivalent relative paths to match")
+	}
+	if sameDir("./db_snapshots", "./reports") {
+		t.Error("Expected different directories not to match")
+	}
+}
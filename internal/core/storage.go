@@ -8,6 +8,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/ntancardoso/dbc/internal/models"
 )
 
@@ -42,14 +43,21 @@ func (s *SnapshotStorage) Save(snapshot *models.SchemaSnapshot) error {
 }
 
 func (s *SnapshotStorage) Load(key string) (*models.SchemaSnapshot, error) {
+	snapshot, _, err := s.LoadWithDigest(key)
+	return snapshot, err
+}
+
+// LoadWithDigest loads a snapshot along with an xxhash64 digest of its file,
+// a cheap identity for the exact snapshot contents.
+func (s *SnapshotStorage) LoadWithDigest(key string) (*models.SchemaSnapshot, string, error) {
 	pattern := filepath.Join(s.baseDir, fmt.Sprintf("%s_*.json", key))
 	matches, err := filepath.Glob(pattern)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find snapshots: %w", err)
+		return nil, "", fmt.Errorf("failed to find snapshots: %w", err)
 	}
 
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("no snapshot found with key: %s", key)
+		return nil, "", fmt.Errorf("no snapshot found with key: %s", key)
 	}
 
 	sort.Strings(matches)
@@ -57,15 +65,16 @@ func (s *SnapshotStorage) Load(key string) (*models.SchemaSnapshot, error) {
 
 	data, err := os.ReadFile(latestFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+		return nil, "", fmt.Errorf("failed to read snapshot: %w", err)
 	}
 
 	var snapshot models.SchemaSnapshot
 	if err := json.Unmarshal(data, &snapshot); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+		return nil, "", fmt.Errorf("failed to unmarshal snapshot: %w", err)
 	}
 
-	return &snapshot, nil
+	digest := fmt.Sprintf("%s:%016x", HashXXHash64, xxhash.Sum64(data))
+	return &snapshot, digest, nil
 }
 
 func (s *SnapshotStorage) List() ([]SnapshotInfo, error) {
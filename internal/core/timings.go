@@ -0,0 +1,47 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Timings records where a compare run spends its time so slow comparisons
+// can be reported with concrete numbers.
+type Timings struct {
+	Load        time.Duration
+	IndexBuild  time.Duration
+	Columns     time.Duration
+	Indexes     time.Duration
+	ForeignKeys time.Duration
+	RowCounts   time.Duration
+	Checksums   time.Duration
+	Format      time.Duration
+
+	CacheHit           bool
+	Tables             int // Tables across both snapshots
+	ColumnsCompared    int // Columns diffed column by column
+	ColumnsNotItemized int // Columns of tables over the column limit, only checked for equality
+}
+
+func (t *Timings) Total() time.Duration {
+	return t.Load + t.IndexBuild + t.Columns + t.Indexes + t.ForeignKeys + t.RowCounts + t.Checksums + t.Format
+}
+
+func (t *Timings) String() string {
+	var b strings.Builder
+	b.WriteString("Timings:\n")
+	fmt.Fprintf(&b, "  Load:           %s\n", t.Load)
+	if t.CacheHit {
+		b.WriteString("  Cache:          hit (diff skipped)\n")
+	}
+	fmt.Fprintf(&b, "  Index Build:    %s (%d tables)\n", t.IndexBuild, t.Tables)
+	fmt.Fprintf(&b, "  Columns:        %s (%d columns, %d not itemized)\n", t.Columns, t.ColumnsCompared, t.ColumnsNotItemized)
+	fmt.Fprintf(&b, "  Indexes:        %s\n", t.Indexes)
+	fmt.Fprintf(&b, "  Foreign Keys:   %s\n", t.ForeignKeys)
+	fmt.Fprintf(&b, "  Row Counts:     %s\n", t.RowCounts)
+	fmt.Fprintf(&b, "  Checksums:      %s\n", t.Checksums)
+	fmt.Fprintf(&b, "  Format:         %s\n", t.Format)
+	fmt.Fprintf(&b, "  Total:          %s\n", t.Total())
+	return b.String()
+}
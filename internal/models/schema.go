@@ -30,7 +30,8 @@ type Table struct {
 	AvgRowLength  int64        `json:"avg_row_length,omitempty"`
 	CreateTime    *time.Time   `json:"create_time,omitempty"`
 	UpdateTime    *time.Time   `json:"update_time,omitempty"`
-	Checksum      string       `json:"checksum,omitempty"` // Optional data checksum
+	Checksum      string       `json:"checksum,omitempty"`    // Optional data checksum
+	PrimaryKey    []string     `json:"primary_key,omitempty"` // Key columns in order, for drivers that don't report primary indexes
 	Columns       []Column     `json:"columns"`
 	Indexes       []Index      `json:"indexes"`
 	ForeignKeys   []ForeignKey `json:"foreign_keys"`
@@ -83,13 +84,17 @@ type ChangeSet struct {
 	TablesRemoved  []Table       `json:"tables_removed"`
 	TablesModified []TableDiff   `json:"tables_modified"`
 	Summary        ChangeSummary `json:"summary"`
+	Sections       []string      `json:"sections,omitempty"` // Change categories compared (empty = all)
+	Warnings       []string      `json:"warnings,omitempty"`
 }
 
 type TableDiff struct {
 	Name               string           `json:"name"`
+	PrimaryKeyChange   *PrimaryKeyDiff  `json:"primary_key_change,omitempty"`
 	ColumnsAdded       []Column         `json:"columns_added,omitempty"`
 	ColumnsRemoved     []Column         `json:"columns_removed,omitempty"`
 	ColumnsModified    []ColumnDiff     `json:"columns_modified,omitempty"`
+	ColumnsSkipped     bool             `json:"columns_skipped,omitempty"` // Column list changed but exceeded the diff limit
 	IndexesAdded       []Index          `json:"indexes_added,omitempty"`
 	IndexesRemoved     []Index          `json:"indexes_removed,omitempty"`
 	IndexesModified    []IndexDiff      `json:"indexes_modified,omitempty"`
@@ -102,6 +107,11 @@ type TableDiff struct {
 	ChecksumChanged    bool             `json:"checksum_changed"`
 }
 
+type PrimaryKeyDiff struct {
+	Before []string `json:"before"` // Key columns in order, empty if no primary key
+	After  []string `json:"after"`
+}
+
 type ColumnDiff struct {
 	Name   string `json:"name"`
 	Before Column `json:"before"`
@@ -124,6 +134,7 @@ type ChangeSummary struct {
 	TablesAdded         int  `json:"tables_added"`
 	TablesRemoved       int  `json:"tables_removed"`
 	TablesModified      int  `json:"tables_modified"`
+	PrimaryKeysChanged  int  `json:"primary_keys_changed"`
 	ColumnsAdded        int  `json:"columns_added"`
 	ColumnsRemoved      int  `json:"columns_removed"`
 	ColumnsModified     int  `json:"columns_modified"`